@@ -9,6 +9,8 @@ package main
 import (
 	"fmt"
 	"os"
+	// Embedded so that ?tz= works in images that ship a single zoneinfo file.
+	_ "time/tzdata"
 
 	"github.com/Zorynix/song-library/internal/app"
 )
@@ -0,0 +1,52 @@
+// Command musicstub serves the music API's GET /info from a fixture file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/musicinfo"
+)
+
+var defaultSong = musicinfo.FakeSong{
+	Group: "Muse",
+	Song:  "Supermassive Black Hole",
+	SongDetail: entity.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\nYou caught me under false pretenses\nHow long before you let me go?\n\nOoh\nYou set my soul alight\nOoh\nYou set my soul alight",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	},
+}
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	songs := flag.String("songs", "", "JSON file with an array of {group, song, releaseDate, text, link}")
+	flag.Parse()
+
+	logger.SetupLogger("info")
+
+	fake := musicinfo.NewFake(defaultSong)
+	if *songs != "" {
+		f, err := os.Open(*songs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fake, err = musicinfo.LoadFake(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	logger.Logger.Info().Str("addr", *addr).Msg("Music API stub is running")
+	if err := http.ListenAndServe(*addr, musicinfo.StubHandler(fake)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
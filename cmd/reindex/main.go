@@ -0,0 +1,19 @@
+// Command reindex rebuilds the search index from library.songs.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Zorynix/song-library/internal/app"
+)
+
+const configPath = "config/config.yaml"
+
+func main() {
+	fmt.Println("Rebuilding search index...")
+	if err := app.Reindex(configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -10,12 +10,20 @@ import (
 
 type (
 	Config struct {
-		App        `yaml:"app"`
-		Server     `yaml:"server"`
-		Log        `yaml:"log"`
-		PG         `yaml:"postgres"`
-		Prometheus `yaml:"prometheus"`
-		MusicAPI   `yaml:"music_api"`
+		App         `yaml:"app"`
+		Server      `yaml:"server"`
+		GRPC        `yaml:"grpc"`
+		Log         `yaml:"log"`
+		PG          `yaml:"postgres"`
+		Prometheus  `yaml:"prometheus"`
+		MusicAPI    `yaml:"music_api"`
+		Idempotency `yaml:"idempotency"`
+		Stats       `yaml:"stats"`
+		Suggest     `yaml:"suggest"`
+		Webhooks    `yaml:"webhooks"`
+		Events      `yaml:"events"`
+		Bulk        `yaml:"bulk"`
+		Search      `yaml:"search"`
 	}
 
 	App struct {
@@ -27,6 +35,10 @@ type (
 		Port int `env-required:"true" yaml:"port" env:"SERVER_PORT"`
 	}
 
+	GRPC struct {
+		Port int `env-required:"true" yaml:"port" env:"GRPC_PORT"`
+	}
+
 	Log struct {
 		Level string `env-required:"true" yaml:"level" env:"LOG_LEVEL"`
 	}
@@ -43,7 +55,52 @@ type (
 	}
 
 	MusicAPI struct {
-		URL string `env-required:"true" yaml:"url" env:"MUSIC_API_URL"`
+		URL            string        `env-required:"true" yaml:"url" env:"MUSIC_API_URL"`
+		AttemptTimeout time.Duration `env-required:"true" yaml:"attempt_timeout" env:"MUSIC_API_ATTEMPT_TIMEOUT"`
+		Timeout        time.Duration `env-required:"true" yaml:"timeout" env:"MUSIC_API_TIMEOUT"`
+		MaxAttempts    int           `env-required:"true" yaml:"max_attempts" env:"MUSIC_API_MAX_ATTEMPTS"`
+		MinBackoff     time.Duration `env-required:"true" yaml:"min_backoff" env:"MUSIC_API_MIN_BACKOFF"`
+		MaxBackoff     time.Duration `env-required:"true" yaml:"max_backoff" env:"MUSIC_API_MAX_BACKOFF"`
+	}
+
+	Idempotency struct {
+		TTL time.Duration `env-required:"true" yaml:"ttl" env:"IDEMPOTENCY_TTL"`
+		// Lease should outlast the slowest request.
+		Lease         time.Duration `env-required:"true" yaml:"lease" env:"IDEMPOTENCY_LEASE"`
+		SweepInterval time.Duration `env-required:"true" yaml:"sweep_interval" env:"IDEMPOTENCY_SWEEP_INTERVAL"`
+	}
+
+	Stats struct {
+		CacheMaxAge time.Duration `env-required:"true" yaml:"cache_max_age" env:"STATS_CACHE_MAX_AGE"`
+	}
+
+	Suggest struct {
+		CacheMaxAge time.Duration `env-required:"true" yaml:"cache_max_age" env:"SUGGEST_CACHE_MAX_AGE"`
+	}
+
+	Webhooks struct {
+		PollInterval time.Duration `env-required:"true" yaml:"poll_interval" env:"WEBHOOK_POLL_INTERVAL"`
+		Timeout      time.Duration `env-required:"true" yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+		MaxAttempts  int           `env-required:"true" yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+		BatchSize    int           `env-required:"true" yaml:"batch_size" env:"WEBHOOK_BATCH_SIZE"`
+		MinBackoff   time.Duration `env-required:"true" yaml:"min_backoff" env:"WEBHOOK_MIN_BACKOFF"`
+		MaxBackoff   time.Duration `env-required:"true" yaml:"max_backoff" env:"WEBHOOK_MAX_BACKOFF"`
+	}
+
+	Events struct {
+		HistorySize int           `env-required:"true" yaml:"history_size" env:"EVENTS_HISTORY_SIZE"`
+		Heartbeat   time.Duration `env-required:"true" yaml:"heartbeat" env:"EVENTS_HEARTBEAT"`
+	}
+
+	Bulk struct {
+		MaxRows int `env-required:"true" yaml:"max_rows" env:"BULK_MAX_ROWS"`
+	}
+
+	Search struct {
+		FuzzyThreshold float64       `env-required:"true" yaml:"fuzzy_threshold" env:"SEARCH_FUZZY_THRESHOLD"`
+		Backend        string        `env-required:"true" yaml:"backend" env:"SEARCH_BACKEND"`
+		IndexDir       string        `env-required:"true" yaml:"index_dir" env:"SEARCH_INDEX_DIR"`
+		TermsRefresh   time.Duration `env-required:"true" yaml:"terms_refresh" env:"SEARCH_TERMS_REFRESH"`
 	}
 )
 
@@ -15,15 +15,154 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/songs": {
+        "/events": {
+            "get": {
+                "description": "Server-Sent Events с событиями song.created, song.updated и song.deleted. Поле id каждого события\nможно передать в заголовке Last-Event-ID (или параметре lastEventId), чтобы получить пропущенные события\nиз ограниченной истории. Если событие уже вытеснено из истории, первым приходит событие reset и клиенту\nнужно перезагрузить данные. Текст песни в событиях не передаётся.",
+                "produces": [
+                    "text/event-stream",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Поток событий песен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID последнего полученного события, если заголовок задать нельзя",
+                        "name": "lastEventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Только события песен этих групп",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SongEvent"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис останавливается",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/lyrics": {
             "get": {
-                "description": "Возвращает список песен с возможностью фильтрации по группе, названию и тексту",
+                "description": "Возвращает песни, в тексте которых встречается q (без учёта регистра), с индексами куплетов\nи HTML-фрагментами вокруг совпадений, выделенных тегом \u003cmark\u003e. Индекс куплета можно передать\nкак offset в GET /songs/{id}/verses. Пагинация идёт по песням; их общее количество\nпередаётся в заголовках X-Total-Count и Link.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Поиск по текстам песен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Искомый фрагмент текста",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит песен",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Найденные песни",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.LyricMatch"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Ссылки на следующую и предыдущую страницы"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество найденных песен"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs": {
+            "get": {
+                "description": "Возвращает список песен с возможностью фильтрации по группе, названию и тексту.\nОбщее количество песен передаётся в заголовках X-Total-Count и Link,\nа при envelope=true ответ оборачивается в entity.Page.\nЕсли по group, song или q ничего не найдено, конверт содержит подсказки didYouMean.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
                 "tags": [
                     "Songs"
                 ],
@@ -47,6 +186,28 @@ const docTemplate = `{
                         "name": "text",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; результаты сортируются по релевантности",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток, сортируя по похожести",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Лимит записей",
@@ -58,6 +219,24 @@ const docTemplate = `{
                         "description": "Смещение",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра, например: group eq 'Muse' and (title contains 'hole' or releaseDate gt '2006-01-01')",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поля через запятую (id, group, title, releaseDate, text, link, tags); по умолчанию все, кроме text",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -68,12 +247,34 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/entity.Song"
                             }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Ссылки на следующую и предыдущую страницы"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество песен"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
@@ -84,13 +285,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "Songs"
                 ],
                 "summary": "Добавить песню",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "Данные песни (group и title обязательны)",
                         "name": "song",
@@ -111,186 +321,2145 @@ const docTemplate = `{
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена во внешнем API",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка внешнего API",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/{id}": {
-            "put": {
-                "description": "Обновляет данные песни по её ID",
+        "/songs/daily": {
+            "get": {
+                "description": "Возвращает песню дня: в один и тот же день в заданном часовом поясе все получают одну и ту же песню.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "Songs"
                 ],
-                "summary": "Обновить песню",
+                "summary": "Получить песню дня",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID песни",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Часовой пояс IANA, например Europe/Moscow; по умолчанию часовой пояс сервера",
+                        "name": "tz",
+                        "in": "query"
                     },
                     {
-                        "description": "Данные песни",
-                        "name": "song",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Song"
-                        }
+                        "type": "string",
+                        "description": "Дата в формате YYYY-MM-DD; по умолчанию сегодня",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленная песня",
+                        "description": "Песня дня",
                         "schema": {
                             "$ref": "#/definitions/entity.Song"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или ID",
+                        "description": "Неверный часовой пояс или дата",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "404": {
-                        "description": "Песня не найдена",
+                        "description": "Библиотека пуста",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет песню по её ID",
+            }
+        },
+        "/songs/facets": {
+            "get": {
+                "description": "Для песен, отобранных теми же фильтрами, что и GET /songs, возвращает количество песен\nпо самым частым группам, годам выпуска и тегам — по убыванию количества. Поле total фасета —\nчисло различных значений. Все фасеты считаются по одному снимку данных.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "Songs"
+                    "Stats"
                 ],
-                "summary": "Удалить песню",
+                "summary": "Фасеты списка песен",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фасеты через запятую (group, year, tag); по умолчанию все",
+                        "name": "facets",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID песни",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Количество значений в каждом фасете (по умолчанию 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Песня успешно удалена",
+                    "200": {
+                        "description": "Фасеты",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Facet"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверные параметры запроса",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Песня не найдена",
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/v1.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/{id}/verses": {
+        "/songs/random": {
             "get": {
-                "description": "Возвращает куплеты песни по её ID с пагинацией",
+                "description": "Возвращает случайную песню среди подходящих под те же фильтры, что и GET /songs.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "Songs"
                 ],
-                "summary": "Получить куплеты песни",
+                "summary": "Получить случайную песню",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID песни",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Лимит куплетов",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Смещение",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; случайная песня выбирается среди найденных",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток, сортируя по похожести",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра, например: group eq 'Muse'",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Случайная песня",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Нет подходящих песен",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}": {
+            "put": {
+                "description": "Обновляет данные песни по её ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Обновить песню",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные песни",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Song"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленная песня",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет песню по её ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Удалить песню",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Песня успешно удалена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/similar": {
+            "get": {
+                "description": "Возвращает песни с наиболее похожими текстами: сравниваются самые характерные для песни слова\nс учётом их редкости в библиотеке (TF-IDF). С boostGroup и boostTags выше поднимаются песни\nтой же группы и с общими тегами. Score сравним только в пределах одного ответа.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Похожие песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество песен (по умолчанию 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поднять песни той же группы",
+                        "name": "boostGroup",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поднять песни с общими тегами",
+                        "name": "boostTags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Похожие песни",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.SimilarSong"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/verses": {
+            "get": {
+                "description": "Возвращает куплеты песни по её ID с пагинацией; каждый куплет содержит свой индекс.\nС unit=line пагинация идёт по строкам вместо куплетов.\nОбщее количество куплетов передаётся в заголовках X-Total-Count и Link,\nа при envelope=true ответ оборачивается в entity.Page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Получить куплеты песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит куплетов",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "verse",
+                            "line"
+                        ],
+                        "type": "string",
+                        "description": "Единица пагинации: verse (по умолчанию) или line",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список куплетов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Verse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Ссылки на следующую и предыдущую страницы"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество куплетов"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или параметры пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/verses/{n}": {
+            "get": {
+                "description": "Возвращает куплет песни по его индексу (с нуля) вместе с общим количеством куплетов.\nС unit=line индекс указывает на строку.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Получить куплет песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Индекс куплета, начиная с 0",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "verse",
+                            "line"
+                        ],
+                        "type": "string",
+                        "description": "Единица: verse (по умолчанию) или line",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Куплет",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SongVerse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или индекс",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или куплет не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs:bulk-delete": {
+            "post": {
+                "description": "Удаляет песни из списка ids или подходящие под filter (те же условия, что у GET /songs) в одной транзакции.\nЕсли выбрано больше песен, чем разрешено настройкой bulk.max_rows, ничего не удаляется.\nС dryRun=true возвращает, что было бы удалено, не меняя данные.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Массовое удаление песен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Выбор песен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkDelete"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат по каждой песне",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Выбрано слишком много песен или ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs:bulk-update": {
+            "post": {
+                "description": "Задаёт поля из set (group, title, releaseDate, link, tags) песням из списка ids или подходящим под filter\nв одной транзакции. Если выбрано больше песен, чем разрешено настройкой bulk.max_rows, ничего не меняется.\nС dryRun=true возвращает песни в том виде, какими они стали бы, не меняя данные.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Массовое обновление песен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Выбор песен и новые значения полей",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат по каждой песне",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Выбрано слишком много песен или ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Возвращает количество песен, средние число куплетов и длину текста,\nа также число песен без текста или ссылки. Учитывает те же фильтры, что и GET /songs.\nОтвет кэшируется (Cache-Control, ETag).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Получить статистику библиотеки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра, например: group eq 'Muse' and releaseDate gt '2006-01-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SongStats"
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Время кэширования ответа"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Тег версии ответа"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Статистика не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/groups": {
+            "get": {
+                "description": "Возвращает количество песен каждой группы по убыванию. Учитывает те же фильтры, что и GET /songs.\nОтвет кэшируется (Cache-Control, ETag).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Количество песен по группам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит записей (по умолчанию 20, не больше 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество песен по группам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.StatBucket"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Тег версии ответа"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество групп"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Статистика не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/tags": {
+            "get": {
+                "description": "Возвращает количество песен с каждым тегом по убыванию. Учитывает те же фильтры, что и GET /songs.\nОтвет кэшируется (Cache-Control, ETag).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Количество песен по тегам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит записей (по умолчанию 20, не больше 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество песен по тегам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.StatBucket"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Тег версии ответа"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество тегов"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Статистика не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/years": {
+            "get": {
+                "description": "Возвращает количество песен по годам выпуска в порядке возрастания года;\nпесни с датой выпуска не в формате ДД.ММ.ГГГГ не учитываются. Учитывает те же фильтры, что и GET /songs.\nОтвет кэшируется (Cache-Control, ETag).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Количество песен по годам выпуска",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Только песни на этом языке; запрос q разбирается по его правилам",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Искать группу и название с учётом опечаток",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение фильтра",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит записей (по умолчанию 20, не больше 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество песен по годам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.StatBucket"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Тег версии ответа"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество лет"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Статистика не изменилась"
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggest": {
+            "get": {
+                "description": "Возвращает значения поля field, начинающиеся с prefix (без учёта регистра), в порядке убывания\nчисла песен с этим значением. Рассчитан на вызов при каждом нажатии клавиши; ответ кэшируется\n(Cache-Control, ETag).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Автодополнение групп и названий",
+                "parameters": [
+                    {
+                        "enum": [
+                            "group",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Поле для подсказок",
+                        "name": "field",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало значения, не короче 2 символов",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество подсказок (по умолчанию 10, не больше 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag ранее полученного ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подсказки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Completion"
+                            }
+                        },
+                        "headers": {
+                            "Cache-Control": {
+                                "type": "string",
+                                "description": "Время кэширования ответа"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Тег версии ответа"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Подсказки не изменились"
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Возвращает все подписки на события песен; секреты не возвращаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/csv",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получить список вебхуков",
+                "responses": {
+                    "200": {
+                        "description": "Список вебхуков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Webhook"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт подписку на события song.created, song.updated и song.deleted.\nДоставки подписываются HMAC-SHA256 в заголовке X-Song-Library-Signature (t=\u003cunix\u003e,v1=\u003chex\u003e)\nот строки \"\u003ct\u003e.\u003cтело запроса\u003e\". Если секрет не указан, он генерируется и возвращается только в этом ответе.\nАдрес должен быть публичным http(s)-адресом: loopback, частные и link-local адреса не принимаются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Создать вебхук",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные вебхука (url и events обязательны)",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданный вебхук с секретом",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Возвращает подписку на события песен по её ID; секрет не возвращается.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получить вебхук",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вебхук",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Вебхук не найден",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет адрес, события и активность вебхука; пустой секрет оставляет прежний.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Обновить вебхук",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные вебхука",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый вебхук",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Вебхук не найден",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет вебхук вместе с историей его доставок",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Удалить вебхук",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Вебхук удалён",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Вебхук не найден",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Возвращает доставки вебхука, начиная с последних. status=dead показывает доставки,\nисчерпавшие попытки, которые можно отправить повторно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получить доставки вебхука",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Статус доставки",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит записей",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Обернуть ответ в конверт пагинации",
+                        "name": "envelope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список доставок",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.WebhookDelivery"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Ссылки на следующую и предыдущую страницы"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество доставок"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Вебхук не найден",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "Ставит доставку обратно в очередь с новым набором попыток.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Повторить доставку вебхука",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID доставки",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Доставка поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Доставка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "406": {
+                        "description": "Неподдерживаемый формат ответа",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.BulkDelete": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "filter": {
+                    "$ref": "#/definitions/entity.SongFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entity.BulkItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "song": {
+                    "$ref": "#/definitions/entity.Song"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.BulkStatus"
+                }
+            }
+        },
+        "entity.BulkResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BulkItem"
+                    }
+                },
+                "matched": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.BulkStatus": {
+            "type": "string",
+            "enum": [
+                "deleted",
+                "updated",
+                "unchanged",
+                "not_found"
+            ],
+            "x-enum-varnames": [
+                "BulkDeleted",
+                "BulkUpdated",
+                "BulkUnchanged",
+                "BulkNotFound"
+            ]
+        },
+        "entity.BulkUpdate": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "filter": {
+                    "$ref": "#/definitions/entity.SongFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Список куплетов",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный ID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "type": "string"
-                        }
+                },
+                "set": {
+                    "$ref": "#/definitions/entity.SongPatch"
+                }
+            }
+        },
+        "entity.Completion": {
+            "type": "object",
+            "properties": {
+                "songs": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryDead"
+            ]
+        },
+        "entity.Facet": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.StatBucket"
                     }
+                },
+                "dimension": {
+                    "$ref": "#/definitions/entity.StatsDimension"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "entity.LyricMatch": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.VerseHit"
+                    }
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SimilarSong": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.Song": {
             "type": "object",
             "properties": {
@@ -300,17 +2469,319 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "language": {
+                    "description": "Language is the lyric language, \"ru\" or \"en\", detected from the text\nunless given; \"\" when neither fits.",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SongEvent": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "song": {
+                    "$ref": "#/definitions/entity.Song"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.SongEventType"
+                }
+            }
+        },
+        "entity.SongEventType": {
+            "type": "string",
+            "enum": [
+                "song.created",
+                "song.updated",
+                "song.deleted"
+            ],
+            "x-enum-varnames": [
+                "SongCreated",
+                "SongUpdated",
+                "SongDeleted"
+            ]
+        },
+        "entity.SongFilter": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "Fields is a sparse fieldset of SongFields; nil selects every field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filter": {
+                    "description": "Filter is a filterql expression.",
+                    "type": "string"
+                },
+                "fuzzy": {
+                    "description": "Fuzzy matches Group and Title by trigram similarity of at least\nFuzzyThreshold instead of by substring, most similar first.",
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "Language keeps only songs with lyrics in that language and stems Query\nby its rules alone.",
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "q": {
+                    "description": "Query is a web-search style full-text query over title, group and\nlyrics; songs matching it are ordered by relevance.",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SongPatch": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
                 "link": {
                     "type": "string"
                 },
                 "releaseDate": {
                     "type": "string"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SongStats": {
+            "type": "object",
+            "properties": {
+                "avgLyricLength": {
+                    "type": "number"
+                },
+                "avgVerseCount": {
+                    "type": "number"
+                },
+                "missingLink": {
+                    "type": "integer"
+                },
+                "missingText": {
+                    "type": "integer"
+                },
+                "songs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SongVerse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "$ref": "#/definitions/entity.VerseUnit"
+                }
+            }
+        },
+        "entity.StatBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.StatsDimension": {
+            "type": "string",
+            "enum": [
+                "group",
+                "year",
+                "tag"
+            ],
+            "x-enum-varnames": [
+                "StatsByGroup",
+                "StatsByYear",
+                "StatsByTag"
+            ]
+        },
+        "entity.Verse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
                 "text": {
                     "type": "string"
+                }
+            }
+        },
+        "entity.VerseHit": {
+            "type": "object",
+            "properties": {
+                "snippet": {
+                    "type": "string"
+                },
+                "verseIndex": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.VerseUnit": {
+            "type": "string",
+            "enum": [
+                "verse",
+                "line"
+            ],
+            "x-enum-varnames": [
+                "VerseUnitStanza",
+                "VerseUnitLine"
+            ]
+        },
+        "entity.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/entity.SongEventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastStatusCode": {
+                    "type": "integer"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.DeliveryStatus"
+                },
+                "webhookId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
                 },
                 "title": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         }
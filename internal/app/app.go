@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,13 +13,19 @@ import (
 	"github.com/Zorynix/song-library/config"
 	_ "github.com/Zorynix/song-library/docs"
 	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/musicinfo"
 	"github.com/Zorynix/song-library/internal/repo"
+	"github.com/Zorynix/song-library/internal/routes/graphql"
+	grpcv1 "github.com/Zorynix/song-library/internal/routes/grpc/v1"
 	v1 "github.com/Zorynix/song-library/internal/routes/http/v1"
 	"github.com/Zorynix/song-library/internal/services"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jmoiron/sqlx"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func Run(configPath string) error {
@@ -41,15 +48,51 @@ func Run(configPath string) error {
 		logger.Logger.Fatal().Err(err).Msg("Failed to run migrations")
 	}
 
-	repos := repo.NewRepositories(db)
+	repos := repo.NewRepositories(db, cfg.PG.URL)
+	index, err := openSearchIndex(context.Background(), cfg, db, repos)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to open search index")
+	}
+	defer index.Close()
+
+	dispatcher := services.NewWebhookDispatcher(repos, services.WebhookDispatcherOptions{
+		PollInterval: cfg.Webhooks.PollInterval,
+		Timeout:      cfg.Webhooks.Timeout,
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		BatchSize:    cfg.Webhooks.BatchSize,
+		MinBackoff:   cfg.Webhooks.MinBackoff,
+		MaxBackoff:   cfg.Webhooks.MaxBackoff,
+	})
+	termRefresher := services.NewSongTermRefresher(repos, cfg.Search.TermsRefresh)
+	keySweeper := services.NewIdempotencySweeper(repos, cfg.Idempotency.SweepInterval)
+	musicInfo := musicinfo.NewHTTPProvider(cfg.MusicAPI.URL, musicinfo.HTTPProviderOptions{
+		AttemptTimeout: cfg.MusicAPI.AttemptTimeout,
+		Timeout:        cfg.MusicAPI.Timeout,
+		MaxAttempts:    cfg.MusicAPI.MaxAttempts,
+		MinBackoff:     cfg.MusicAPI.MinBackoff,
+		MaxBackoff:     cfg.MusicAPI.MaxBackoff,
+	})
+	broadcaster := services.NewSongEventBroadcaster(repos, cfg.Events.HistorySize)
 	services := services.NewServices(services.ServicesDependencies{
-		Repos:       repos,
-		MusicAPIURL: cfg.MusicAPI.URL,
+		Repos:            repos,
+		MusicInfo:        musicInfo,
+		IdempotencyTTL:   cfg.Idempotency.TTL,
+		IdempotencyLease: cfg.Idempotency.Lease,
+		Events:           broadcaster,
+		Search:           index,
+		BulkMaxRows:      cfg.Bulk.MaxRows,
+		FuzzyThreshold:   cfg.Search.FuzzyThreshold,
+	})
+	handler := v1.NewHandler(services, v1.Options{
+		StatsMaxAge:     cfg.Stats.CacheMaxAge,
+		SuggestMaxAge:   cfg.Suggest.CacheMaxAge,
+		EventsHeartbeat: cfg.Events.Heartbeat,
 	})
-	handler := v1.NewHandler(services)
 
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 	r.Route("/api/v1", handler.Register)
+	r.Handle("/graphql", graphql.NewHandler(services))
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
 	apiServer := &http.Server{
@@ -57,6 +100,19 @@ func Run(configPath string) error {
 		Handler: r,
 	}
 
+	broadcastCtx, stopBroadcaster := context.WithCancel(context.Background())
+	broadcasterDone := make(chan struct{})
+	go func() {
+		defer close(broadcasterDone)
+		broadcaster.Run(broadcastCtx)
+	}()
+	// Close event streams first, or Shutdown waits for them.
+	apiServer.RegisterOnShutdown(stopBroadcaster)
+
+	grpcServer := grpc.NewServer()
+	grpcv1.NewServer(services).Register(grpcServer)
+	reflection.Register(grpcServer)
+
 	metricsServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Prometheus.MetricsPort),
 		Handler: promhttp.Handler(),
@@ -69,6 +125,38 @@ func Run(configPath string) error {
 		}
 	}()
 
+	go func() {
+		logger.Logger.Info().Msgf("Starting gRPC server on port %d", cfg.GRPC.Port)
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to listen for gRPC server")
+		}
+		if err := grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+			logger.Logger.Fatal().Err(err).Msg("Failed to start gRPC server")
+		}
+	}()
+
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		dispatcher.Run(dispatchCtx)
+	}()
+
+	refreshCtx, stopRefresher := context.WithCancel(context.Background())
+	refresherDone := make(chan struct{})
+	go func() {
+		defer close(refresherDone)
+		termRefresher.Run(refreshCtx)
+	}()
+
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	sweeperDone := make(chan struct{})
+	go func() {
+		defer close(sweeperDone)
+		keySweeper.Run(sweepCtx)
+	}()
+
 	go func() {
 		logger.Logger.Info().Msgf("Starting metrics server on port %d", cfg.Prometheus.MetricsPort)
 		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -87,6 +175,14 @@ func Run(configPath string) error {
 	if err := apiServer.Shutdown(ctx); err != nil {
 		logger.Logger.Fatal().Err(err).Msg("API server forced to shutdown")
 	}
+	<-broadcasterDone
+	grpcServer.GracefulStop()
+	stopDispatcher()
+	<-dispatcherDone
+	stopRefresher()
+	<-refresherDone
+	stopSweeper()
+	<-sweeperDone
 	if err := metricsServer.Shutdown(ctx); err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Metrics server forced to shutdown")
 	}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zorynix/song-library/config"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+	"github.com/Zorynix/song-library/internal/search"
+	"github.com/jmoiron/sqlx"
+)
+
+func openSearchIndex(ctx context.Context, cfg *config.Config, db *sqlx.DB, repos *repo.Repositories) (search.SearchIndex, error) {
+	index, err := search.Open(cfg.Search.Backend, db, cfg.Search.IndexDir)
+	if err != nil {
+		return nil, err
+	}
+	if embedded, ok := index.(*search.EmbeddedIndex); ok && embedded.IsNew() {
+		logger.Logger.Info().Str("dir", cfg.Search.IndexDir).Msg("Building new search index")
+		if err := index.Rebuild(ctx, repos.Song.AllSongs(ctx)); err != nil {
+			index.Close()
+			return nil, err
+		}
+	}
+	return index, nil
+}
+
+// Reindex rebuilds the search index; the embedded one only while the server is stopped.
+func Reindex(configPath string) error {
+	cfg, err := config.NewConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger.SetupLogger(cfg.Log.Level)
+
+	db, err := sqlx.Connect("postgres", cfg.PG.URL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	defer db.Close()
+
+	if err := RunMigrations(cfg.PG.URL); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	ctx := context.Background()
+	repos := repo.NewRepositories(db, cfg.PG.URL)
+	index, err := search.Open(cfg.Search.Backend, db, cfg.Search.IndexDir)
+	if err != nil {
+		return err
+	}
+	defer index.Close()
+
+	logger.Logger.Info().Str("backend", cfg.Search.Backend).Msg("Rebuilding search index")
+	if err := index.Rebuild(ctx, repos.Song.AllSongs(ctx)); err != nil {
+		return err
+	}
+	logger.Logger.Info().Str("backend", cfg.Search.Backend).Msg("Search index rebuilt successfully")
+	return nil
+}
@@ -0,0 +1,17 @@
+// Package backoff spaces out the retries of failed requests.
+package backoff
+
+import (
+	mathrand "math/rand/v2"
+	"time"
+)
+
+// Exponential doubles first per attempt up to limit, with ±20% jitter.
+func Exponential(attempt int, first, limit time.Duration) time.Duration {
+	delay := limit
+	if shift := attempt - 1; shift >= 0 && shift < 32 && first<<shift < limit {
+		delay = first << shift
+	}
+	jitter := 0.8 + 0.4*mathrand.Float64()
+	return time.Duration(float64(delay) * jitter)
+}
@@ -0,0 +1,28 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 5, want: 10 * time.Second},
+		{attempt: 40, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			got := Exponential(tt.attempt, time.Second, 10*time.Second)
+			if low, high := tt.want*8/10, tt.want*12/10; got < low || got > high {
+				t.Fatalf("Exponential(%d) = %v, want within [%v, %v]", tt.attempt, got, low, high)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "encoding/xml"
+
+// SongSelector picks songs either by id or by a filter.
+type SongSelector struct {
+	IDs    []int64     `json:"ids,omitempty"`
+	Filter *SongFilter `json:"filter,omitempty"`
+}
+
+type BulkDelete struct {
+	SongSelector
+	DryRun bool `json:"dryRun"`
+}
+
+// SongPatch holds the fields a bulk update sets; nil fields are kept.
+type SongPatch struct {
+	Group       *string   `json:"group,omitempty"`
+	Title       *string   `json:"title,omitempty"`
+	ReleaseDate *string   `json:"releaseDate,omitempty"`
+	Link        *string   `json:"link,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+}
+
+type BulkUpdate struct {
+	SongSelector
+	Set    SongPatch `json:"set"`
+	DryRun bool      `json:"dryRun"`
+}
+
+type BulkStatus string
+
+const (
+	BulkDeleted   BulkStatus = "deleted"
+	BulkUpdated   BulkStatus = "updated"
+	BulkUnchanged BulkStatus = "unchanged"
+	BulkNotFound  BulkStatus = "not_found"
+)
+
+// BulkItem is the outcome of a bulk operation for one song.
+type BulkItem struct {
+	ID     int64      `json:"id" xml:"id"`
+	Status BulkStatus `json:"status" xml:"status"`
+	Song   *Song      `json:"song,omitempty" xml:"song,omitempty"`
+}
+
+type BulkResult struct {
+	XMLName xml.Name   `json:"-" xml:"bulkResult"`
+	DryRun  bool       `json:"dryRun" xml:"dryRun"`
+	Matched int        `json:"matched" xml:"matched"`
+	Changed int        `json:"changed" xml:"changed"`
+	Items   []BulkItem `json:"items" xml:"items>item"`
+}
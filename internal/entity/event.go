@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type SongEventType string
+
+const (
+	SongCreated SongEventType = "song.created"
+	SongUpdated SongEventType = "song.updated"
+	SongDeleted SongEventType = "song.deleted"
+)
+
+var SongEventTypes = []SongEventType{SongCreated, SongUpdated, SongDeleted}
+
+type SongEventFunc func(song Song) SongEvent
+
+// SongEvent describes a committed change to a song.
+type SongEvent struct {
+	ID         string        `json:"id"`
+	Type       SongEventType `json:"type"`
+	OccurredAt time.Time     `json:"occurredAt"`
+	SongID     int64         `json:"songId"`
+	Song       *Song         `json:"song,omitempty"`
+}
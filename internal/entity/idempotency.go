@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord is a stored response for an Idempotency-Key.
+type IdempotencyRecord struct {
+	Key          string    `db:"key"`
+	RequestHash  string    `db:"request_hash"`
+	StatusCode   *int      `db:"status_code"`
+	ContentType  string    `db:"content_type"`
+	ResponseBody []byte    `db:"response_body"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
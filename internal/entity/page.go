@@ -0,0 +1,14 @@
+package entity
+
+import "encoding/xml"
+
+type Page struct {
+	XMLName    xml.Name     `json:"-" xml:"page"`
+	Items      any          `json:"items" xml:"items>item"`
+	Total      int          `json:"total" xml:"total"`
+	Limit      int          `json:"limit" xml:"limit"`
+	Offset     int          `json:"offset" xml:"offset"`
+	Next       string       `json:"next,omitempty" xml:"next,omitempty"`
+	Prev       string       `json:"prev,omitempty" xml:"prev,omitempty"`
+	DidYouMean []Suggestion `json:"didYouMean,omitempty" xml:"didYouMean>suggestion,omitempty"`
+}
@@ -0,0 +1,61 @@
+package entity
+
+type LyricSearch struct {
+	Query  string `json:"q"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+type LyricMatch struct {
+	SongID int64      `json:"songId" xml:"songId"`
+	Group  string     `json:"group" xml:"group"`
+	Title  string     `json:"title" xml:"title"`
+	Hits   []VerseHit `json:"hits" xml:"hits>hit"`
+}
+
+type LyricVerses struct {
+	SongID int64
+	Group  string
+	Title  string
+	Verses []Verse
+}
+
+// VerseHit points at a verse containing the query; Snippet is HTML.
+type VerseHit struct {
+	VerseIndex int    `json:"verseIndex" xml:"verseIndex"`
+	Snippet    string `json:"snippet" xml:"snippet"`
+}
+
+type Suggestion struct {
+	Field string  `json:"field" xml:"field" db:"-"`
+	Value string  `json:"value" xml:"value" db:"value"`
+	Score float64 `json:"score" xml:"score" db:"score"`
+}
+
+type NameCompletion struct {
+	Field  string `json:"field"`
+	Prefix string `json:"prefix"`
+	Limit  int    `json:"limit"`
+}
+
+type Completion struct {
+	Value string `json:"value" xml:"value" db:"value"`
+	Songs int    `json:"songs" xml:"songs" db:"songs"`
+}
+
+type SimilarQuery struct {
+	SongID     int64 `json:"songId"`
+	Limit      int   `json:"limit"`
+	BoostGroup bool  `json:"boostGroup"`
+	BoostTags  bool  `json:"boostTags"`
+}
+
+type SimilarSong struct {
+	ID          int64    `json:"id" xml:"id" db:"id"`
+	Group       string   `json:"group" xml:"group" db:"group"`
+	Title       string   `json:"title" xml:"title" db:"title"`
+	ReleaseDate string   `json:"releaseDate" xml:"releaseDate" db:"release_date"`
+	Link        string   `json:"link" xml:"link" db:"link"`
+	Tags        []string `json:"tags" xml:"tags>tag" db:"tags"`
+	Score       float64  `json:"score" xml:"score" db:"score"`
+}
@@ -1,24 +1,68 @@
 package entity
 
+import "encoding/xml"
+
 type Song struct {
-	ID          int64  `json:"id" db:"id"`
-	Group       string `json:"group" db:"group"`
-	Title       string `json:"title" db:"title"`
-	ReleaseDate string `json:"releaseDate" db:"release_date"`
-	Text        string `json:"text" db:"text"`
-	Link        string `json:"link" db:"link"`
+	ID          int64    `json:"id" xml:"id" db:"id"`
+	Group       string   `json:"group" xml:"group" db:"group"`
+	Title       string   `json:"title" xml:"title" db:"title"`
+	ReleaseDate string   `json:"releaseDate" xml:"releaseDate" db:"release_date"`
+	Text        string   `json:"text" xml:"text" db:"text"`
+	Link        string   `json:"link" xml:"link" db:"link"`
+	Tags        []string `json:"tags" xml:"tags>tag" db:"tags"`
+	Language    string   `json:"language" xml:"language" db:"language"`
+}
+
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
 }
 
+var SongFields = []string{"id", "group", "title", "releaseDate", "text", "link", "tags", "language"}
+
+var DefaultSongListFields = []string{"id", "group", "title", "releaseDate", "link", "tags", "language"}
+
 type SongFilter struct {
-	Group  string `json:"group"`
-	Title  string `json:"title"`
-	Text   string `json:"text"`
-	Limit  int    `json:"limit"`
-	Offset int    `json:"offset"`
+	Group string `json:"group"`
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	Query string `json:"q"`
+	// SearchIDs are filled by the service from the search index, best first.
+	SearchIDs      []int64  `json:"-"`
+	Language       string   `json:"lang"`
+	Fuzzy          bool     `json:"fuzzy"`
+	FuzzyThreshold float64  `json:"-"`
+	Limit          int      `json:"limit"`
+	Offset         int      `json:"offset"`
+	Fields         []string `json:"fields"`
+	Filter         string   `json:"filter"`
 }
 
+type VerseUnit string
+
+const (
+	VerseUnitStanza VerseUnit = "verse"
+	VerseUnitLine   VerseUnit = "line"
+)
+
 type VersePagination struct {
-	SongID int64 `json:"song_id"`
-	Limit  int   `json:"limit"`
-	Offset int   `json:"offset"`
+	SongID int64     `json:"song_id"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+	Unit   VerseUnit `json:"unit"`
+}
+
+type Verse struct {
+	Index int    `json:"index" xml:"index"`
+	Text  string `json:"text" xml:"text"`
+}
+
+type SongVerse struct {
+	XMLName xml.Name  `json:"-" xml:"verse"`
+	SongID  int64     `json:"songId" xml:"songId"`
+	Unit    VerseUnit `json:"unit" xml:"unit"`
+	Index   int       `json:"index" xml:"index"`
+	Total   int       `json:"total" xml:"total"`
+	Text    string    `json:"text" xml:"text"`
 }
@@ -0,0 +1,40 @@
+package entity
+
+import "encoding/xml"
+
+// SongStats summarizes songs; averages only count songs with lyrics.
+type SongStats struct {
+	XMLName        xml.Name `json:"-" xml:"stats" db:"-"`
+	Songs          int      `json:"songs" xml:"songs" db:"songs"`
+	AvgVerseCount  float64  `json:"avgVerseCount" xml:"avgVerseCount" db:"avg_verse_count"`
+	AvgLyricLength float64  `json:"avgLyricLength" xml:"avgLyricLength" db:"avg_lyric_length"`
+	MissingText    int      `json:"missingText" xml:"missingText" db:"missing_text"`
+	MissingLink    int      `json:"missingLink" xml:"missingLink" db:"missing_link"`
+}
+
+type StatsDimension string
+
+const (
+	StatsByGroup StatsDimension = "group"
+	StatsByYear  StatsDimension = "year"
+	StatsByTag   StatsDimension = "tag"
+)
+
+type StatBucket struct {
+	Key   string `json:"key" xml:"key" db:"key"`
+	Count int    `json:"count" xml:"count" db:"count"`
+}
+
+var StatsDimensions = []StatsDimension{StatsByGroup, StatsByYear, StatsByTag}
+
+type FacetQuery struct {
+	Filter     SongFilter       `json:"filter"`
+	Dimensions []StatsDimension `json:"facets"`
+	Limit      int              `json:"limit"`
+}
+
+type Facet struct {
+	Dimension StatsDimension `json:"dimension" xml:"dimension"`
+	Total     int            `json:"total" xml:"total"`
+	Buckets   []StatBucket   `json:"buckets" xml:"buckets>bucket"`
+}
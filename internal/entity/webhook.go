@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Webhook struct {
+	ID        int64     `json:"id" xml:"id" db:"id"`
+	URL       string    `json:"url" xml:"url" db:"url"`
+	Secret    string    `json:"secret,omitempty" xml:"secret,omitempty" db:"secret"`
+	Events    []string  `json:"events" xml:"events>event" db:"events"`
+	Active    bool      `json:"active" xml:"active" db:"active"`
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" db:"created_at"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id" xml:"id" db:"id"`
+	WebhookID      int64           `json:"webhookId" xml:"webhookId" db:"webhook_id"`
+	Event          SongEventType   `json:"event" xml:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" xml:"payload" db:"payload" swaggertype:"object"`
+	Status         DeliveryStatus  `json:"status" xml:"status" db:"status"`
+	Attempts       int             `json:"attempts" xml:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt" xml:"nextAttemptAt" db:"next_attempt_at"`
+	LastStatusCode *int            `json:"lastStatusCode,omitempty" xml:"lastStatusCode,omitempty" db:"last_status_code"`
+	LastError      string          `json:"lastError,omitempty" xml:"lastError,omitempty" db:"last_error"`
+	CreatedAt      time.Time       `json:"createdAt" xml:"createdAt" db:"created_at"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty" xml:"deliveredAt,omitempty" db:"delivered_at"`
+}
+
+type DeliveryJob struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+type DeliveryFilter struct {
+	WebhookID int64          `json:"webhook_id"`
+	Status    DeliveryStatus `json:"status"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+}
@@ -0,0 +1,46 @@
+package errors
+
+import "errors"
+
+const (
+	CodeSongInfoNotFound  = "song_info_not_found"
+	CodeNotFound          = "not_found"
+	CodeInvalidInput      = "invalid_input"
+	CodeBadRequest        = "bad_request"
+	CodeNotAcceptable     = "not_acceptable"
+	CodeKeyInProgress     = "idempotency_key_in_progress"
+	CodeKeyReused         = "idempotency_key_reused"
+	CodeMusicAPIFailed    = "music_api_failed"
+	CodeOperationFailed   = "operation_failed"
+	CodeUnavailable       = "unavailable"
+	CodeBulkLimitExceeded = "bulk_limit_exceeded"
+	CodeInternal          = "internal_error"
+)
+
+// codes is checked in order, so more specific sentinels go first.
+var codes = []struct {
+	target error
+	code   string
+}{
+	{ErrSongInfoNotFound, CodeSongInfoNotFound},
+	{ErrNotFound, CodeNotFound},
+	{ErrInvalidInput, CodeInvalidInput},
+	{ErrBadRequest, CodeBadRequest},
+	{ErrNotAcceptable, CodeNotAcceptable},
+	{ErrKeyInProgress, CodeKeyInProgress},
+	{ErrKeyReused, CodeKeyReused},
+	{ErrMusicAPIFailed, CodeMusicAPIFailed},
+	{ErrOperationFailed, CodeOperationFailed},
+	{ErrUnavailable, CodeUnavailable},
+	{ErrBulkLimitExceeded, CodeBulkLimitExceeded},
+}
+
+// Classify returns the sentinel err matches and its code.
+func Classify(err error) (target error, code string) {
+	for _, c := range codes {
+		if errors.Is(err, c.target) {
+			return c.target, c.code
+		}
+	}
+	return ErrInternal, CodeInternal
+}
@@ -1,11 +1,43 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrNotFound        = errors.New("resource not found")
-	ErrInternal        = errors.New("internal server error")
-	ErrBadRequest      = errors.New("bad request")
-	ErrInvalidInput    = errors.New("invalid input data")
-	ErrOperationFailed = errors.New("operation failed")
+	ErrNotFound          = errors.New("resource not found")
+	ErrInternal          = errors.New("internal server error")
+	ErrBadRequest        = errors.New("bad request")
+	ErrInvalidInput      = errors.New("invalid input data")
+	ErrOperationFailed   = errors.New("operation failed")
+	ErrSongInfoNotFound  = errors.New("song not found in music API")
+	ErrMusicAPIFailed    = errors.New("music API request failed")
+	ErrKeyInProgress     = errors.New("request with this idempotency key is still in progress")
+	ErrKeyReused         = errors.New("idempotency key was already used with a different request")
+	ErrNotAcceptable     = errors.New("requested media type is not supported")
+	ErrUnavailable       = errors.New("service is shutting down")
+	ErrBulkLimitExceeded = errors.New("bulk operation matches too many songs")
 )
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError matches ErrInvalidInput with errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+	return ErrInvalidInput.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
@@ -0,0 +1,75 @@
+package filterql
+
+import "time"
+
+// Expr is a node of a parsed filter expression.
+type Expr interface {
+	expr()
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+type Comparison struct {
+	Field  string
+	Op     Op
+	Values []Value
+}
+
+func (And) expr()        {}
+func (Or) expr()         {}
+func (Not) expr()        {}
+func (Comparison) expr() {}
+
+type Op string
+
+const (
+	OpEq         Op = "eq"
+	OpNe         Op = "ne"
+	OpContains   Op = "contains"
+	OpStartsWith Op = "startswith"
+	OpGt         Op = "gt"
+	OpGe         Op = "ge"
+	OpLt         Op = "lt"
+	OpLe         Op = "le"
+	OpIn         Op = "in"
+)
+
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeNumber
+	TypeDate
+)
+
+type Value struct {
+	String string
+	Number int64
+	Date   time.Time
+}
+
+var Fields = map[string]FieldType{
+	"id":          TypeNumber,
+	"group":       TypeString,
+	"title":       TypeString,
+	"releaseDate": TypeDate,
+	"text":        TypeString,
+	"link":        TypeString,
+	"language":    TypeString,
+}
+
+var operators = map[FieldType][]Op{
+	TypeString: {OpEq, OpNe, OpContains, OpStartsWith, OpIn},
+	TypeNumber: {OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpIn},
+	TypeDate:   {OpEq, OpNe, OpGt, OpGe, OpLt, OpLe, OpIn},
+}
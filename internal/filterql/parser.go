@@ -0,0 +1,218 @@
+package filterql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxDepth = 32
+
+var dateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+type SyntaxError struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d near %s", e.Message, e.Pos, e.Token)
+}
+
+// Parse turns a filter expression such as
+//
+//	group eq 'Muse' and (title contains 'hole' or releaseDate gt '2006-01-01')
+//
+// into an Expr.
+func Parse(input string) (Expr, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorAt(tok, "expected \"and\", \"or\" or end of input")
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorAt(tok token, message string) error {
+	return &SyntaxError{Pos: tok.pos, Token: tok.String(), Message: message}
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot(depth int) (Expr, error) {
+	if depth > maxDepth {
+		return nil, p.errorAt(p.peek(), "expression is nested too deeply")
+	}
+	if p.peek().is("not") {
+		p.next()
+		expr, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (Expr, error) {
+	tok := p.peek()
+	if tok.kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorAt(closing, "expected \")\"")
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenIdent {
+		return nil, p.errorAt(fieldTok, "expected a field name")
+	}
+	fieldType, ok := Fields[fieldTok.text]
+	if !ok {
+		return nil, p.errorAt(fieldTok, "unknown field, expected one of "+strings.Join(fieldNames(), ", "))
+	}
+
+	opTok := p.next()
+	op := Op(strings.ToLower(opTok.text))
+	if opTok.kind != tokenIdent || !slices.Contains(operators[fieldType], op) {
+		return nil, p.errorAt(opTok, fmt.Sprintf("expected an operator for %s: %s", fieldTok.text, joinOps(operators[fieldType])))
+	}
+
+	if op != OpIn {
+		value, err := p.parseValue(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Comparison{Field: fieldTok.text, Op: op, Values: []Value{value}}, nil
+	}
+
+	if tok := p.next(); tok.kind != tokenLParen {
+		return nil, p.errorAt(tok, "expected \"(\" after in")
+	}
+	var values []Value
+	for {
+		value, err := p.parseValue(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		if tok.kind == tokenRParen {
+			break
+		}
+		if tok.kind != tokenComma {
+			return nil, p.errorAt(tok, "expected \",\" or \")\"")
+		}
+	}
+	return Comparison{Field: fieldTok.text, Op: op, Values: values}, nil
+}
+
+func (p *parser) parseValue(fieldType FieldType) (Value, error) {
+	tok := p.next()
+	switch fieldType {
+	case TypeNumber:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if tok.kind != tokenNumber || err != nil {
+			return Value{}, p.errorAt(tok, "expected an integer")
+		}
+		return Value{Number: n}, nil
+	case TypeDate:
+		if tok.kind == tokenString {
+			for _, layout := range dateLayouts {
+				if date, err := time.Parse(layout, tok.text); err == nil {
+					return Value{Date: date}, nil
+				}
+			}
+		}
+		return Value{}, p.errorAt(tok, "expected a quoted date as 'YYYY-MM-DD' or 'DD.MM.YYYY'")
+	default:
+		if tok.kind != tokenString {
+			return Value{}, p.errorAt(tok, "expected a quoted string")
+		}
+		return Value{String: tok.text}, nil
+	}
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(Fields))
+	for name := range Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func joinOps(ops []Op) string {
+	names := make([]string, 0, len(ops))
+	for _, op := range ops {
+		names = append(names, string(op))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,108 @@
+package filterql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	date := time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  Expr
+	}{
+		{
+			input: "group eq 'Muse'",
+			want:  Comparison{Field: "group", Op: OpEq, Values: []Value{{String: "Muse"}}},
+		},
+		{
+			input: `title CONTAINS 'don''t' And id GE 10`,
+			want: And{
+				Left:  Comparison{Field: "title", Op: OpContains, Values: []Value{{String: "don't"}}},
+				Right: Comparison{Field: "id", Op: OpGe, Values: []Value{{Number: 10}}},
+			},
+		},
+		{
+			input: "group eq 'a' or group eq 'b' and not id eq 1",
+			want: Or{
+				Left: Comparison{Field: "group", Op: OpEq, Values: []Value{{String: "a"}}},
+				Right: And{
+					Left:  Comparison{Field: "group", Op: OpEq, Values: []Value{{String: "b"}}},
+					Right: Not{Expr: Comparison{Field: "id", Op: OpEq, Values: []Value{{Number: 1}}}},
+				},
+			},
+		},
+		{
+			input: "(group eq 'a' or group eq 'b') and id lt 5",
+			want: And{
+				Left: Or{
+					Left:  Comparison{Field: "group", Op: OpEq, Values: []Value{{String: "a"}}},
+					Right: Comparison{Field: "group", Op: OpEq, Values: []Value{{String: "b"}}},
+				},
+				Right: Comparison{Field: "id", Op: OpLt, Values: []Value{{Number: 5}}},
+			},
+		},
+		{
+			input: "releaseDate in ('2006-07-16', '16.07.2006')",
+			want:  Comparison{Field: "releaseDate", Op: OpIn, Values: []Value{{Date: date}, {Date: date}}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %#v, want %#v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{input: "", pos: 1},
+		{input: "genre eq 'rock'", pos: 1},
+		{input: "id contains '1'", pos: 4},
+		{input: "group eq 1", pos: 10},
+		{input: "id eq 'one'", pos: 7},
+		{input: "releaseDate gt '2006/07/16'", pos: 16},
+		{input: "group eq 'Muse", pos: 10},
+		{input: "(group eq 'a'", pos: 14},
+		{input: "group eq 'a' group eq 'b'", pos: 14},
+		{input: "id in (1 2)", pos: 10},
+		{input: "group eq 'a' & id eq 1", pos: 14},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) error = %v, want *SyntaxError", tt.input, err)
+			continue
+		}
+		if syntaxErr.Pos != tt.pos {
+			t.Errorf("Parse(%q) error at position %d, want %d: %v", tt.input, syntaxErr.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestParseDepth(t *testing.T) {
+	input := "id eq 1"
+	for range maxDepth + 1 {
+		input = "(" + input + ")"
+	}
+	if _, err := Parse(input); err == nil {
+		t.Errorf("Parse() of %d nested groups succeeded, want an error", maxDepth+1)
+	}
+	if _, err := Parse("((id eq 1))"); err != nil {
+		t.Errorf("Parse() of two nested groups error = %v", err)
+	}
+}
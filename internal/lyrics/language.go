@@ -0,0 +1,30 @@
+package lyrics
+
+import "unicode"
+
+const (
+	LanguageRussian = "ru"
+	LanguageEnglish = "en"
+)
+
+// DetectLanguage picks the alphabet with more letters, "" for neither.
+func DetectLanguage(text string) string {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			latin++
+		}
+	}
+
+	switch {
+	case cyrillic > latin:
+		return LanguageRussian
+	case latin > 0:
+		return LanguageEnglish
+	default:
+		return ""
+	}
+}
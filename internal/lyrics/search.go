@@ -0,0 +1,74 @@
+package lyrics
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightPre  = "<mark>"
+	highlightPost = "</mark>"
+	ellipsis      = "…"
+)
+
+// Highlight returns an HTML snippet around the first match of query in verse.
+func Highlight(verse, query string, radius int) string {
+	text := []rune(verse)
+	matches := findFold(text, []rune(query))
+	if len(matches) == 0 {
+		end := min(2*radius, len(text))
+		snippet := html.EscapeString(string(text[:end]))
+		if end < len(text) {
+			snippet += ellipsis
+		}
+		return snippet
+	}
+
+	queryLen := len([]rune(query))
+	start := max(matches[0]-radius, 0)
+	end := min(matches[0]+queryLen+radius, len(text))
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+	pos := start
+	for _, m := range matches {
+		if m < pos || m+queryLen > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[pos:m])))
+		b.WriteString(highlightPre)
+		b.WriteString(html.EscapeString(string(text[m : m+queryLen])))
+		b.WriteString(highlightPost)
+		pos = m + queryLen
+	}
+	b.WriteString(html.EscapeString(string(text[pos:end])))
+	if end < len(text) {
+		b.WriteString(ellipsis)
+	}
+	return b.String()
+}
+
+func findFold(text, query []rune) []int {
+	if len(query) == 0 {
+		return nil
+	}
+
+	var matches []int
+	for i := 0; i+len(query) <= len(text); i++ {
+		matched := true
+		for j, r := range query {
+			if unicode.ToLower(text[i+j]) != unicode.ToLower(r) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, i)
+			i += len(query) - 1
+		}
+	}
+	return matches
+}
@@ -0,0 +1,71 @@
+package lyrics
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		verse  string
+		query  string
+		radius int
+		want   string
+	}{
+		{
+			name:   "whole verse",
+			verse:  "You set my soul alight",
+			query:  "soul",
+			radius: 20,
+			want:   "You set my <mark>soul</mark> alight",
+		},
+		{
+			name:   "ignores case",
+			verse:  "Ooh baby, OOH",
+			query:  "ooh",
+			radius: 20,
+			want:   "<mark>Ooh</mark> baby, <mark>OOH</mark>",
+		},
+		{
+			name:   "trims around the first match",
+			verse:  "one two three four five",
+			query:  "three",
+			radius: 4,
+			want:   "…two <mark>three</mark> fou…",
+		},
+		{
+			name:   "skips matches outside the snippet",
+			verse:  "la la la la",
+			query:  "la",
+			radius: 3,
+			want:   "<mark>la</mark> <mark>la</mark>…",
+		},
+		{
+			name:   "escapes html",
+			verse:  "<b>Ёлка</b> & ёлка",
+			query:  "ЁЛКА",
+			radius: 20,
+			want:   "&lt;b&gt;<mark>Ёлка</mark>&lt;/b&gt; &amp; <mark>ёлка</mark>",
+		},
+		{
+			name:   "no match",
+			verse:  "Supermassive <black> hole",
+			query:  "muse",
+			radius: 7,
+			want:   "Supermassive &lt;…",
+		},
+		{
+			name:   "no match in a short verse",
+			verse:  "Ooh",
+			query:  "muse",
+			radius: 7,
+			want:   "Ooh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.verse, tt.query, tt.radius); got != tt.want {
+				t.Errorf("Highlight(%q, %q, %d) = %q, want %q", tt.verse, tt.query, tt.radius, got, tt.want)
+			}
+		})
+	}
+}
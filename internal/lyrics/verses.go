@@ -0,0 +1,34 @@
+package lyrics
+
+import "strings"
+
+const verseSeparator = "\n\n"
+
+func Verses(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, verseSeparator)
+}
+
+func Lines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func Page(verses []string, limit, offset int) []string {
+	if offset >= len(verses) {
+		return []string{}
+	}
+
+	end := offset + limit
+	if limit <= 0 || end > len(verses) {
+		end = len(verses)
+	}
+	return verses[offset:end]
+}
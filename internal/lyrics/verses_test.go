@@ -0,0 +1,69 @@
+package lyrics
+
+import (
+	"slices"
+	"testing"
+)
+
+const testLyrics = "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\n\nOoh\nYou set my soul alight\n\n\nOoh"
+
+func TestVerses(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "", want: []string{}},
+		{text: "One line", want: []string{"One line"}},
+		{
+			text: testLyrics,
+			want: []string{
+				"Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?",
+				"Ooh\nYou set my soul alight",
+				"\nOoh",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Verses(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Verses(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	want := []string{
+		"Ooh baby, don't you know I suffer?",
+		"Ooh baby, can you hear me moan?",
+		"Ooh",
+		"You set my soul alight",
+		"Ooh",
+	}
+	if got := Lines(testLyrics); !slices.Equal(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+	if got := Lines(""); len(got) != 0 {
+		t.Errorf("Lines(\"\") = %q, want none", got)
+	}
+}
+
+func TestPage(t *testing.T) {
+	verses := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		limit, offset int
+		want          []string
+	}{
+		{limit: 2, offset: 0, want: []string{"a", "b"}},
+		{limit: 2, offset: 3, want: []string{"d"}},
+		{limit: 0, offset: 1, want: []string{"b", "c", "d"}},
+		{limit: 2, offset: 4, want: []string{}},
+		{limit: 2, offset: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := Page(verses, tt.limit, tt.offset); !slices.Equal(got, tt.want) {
+			t.Errorf("Page(limit %d, offset %d) = %q, want %q", tt.limit, tt.offset, got, tt.want)
+		}
+	}
+}
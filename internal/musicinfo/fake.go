@@ -0,0 +1,68 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+type FakeSong struct {
+	Group string `json:"group"`
+	Song  string `json:"song"`
+	entity.SongDetail
+}
+
+// Fake is an in-memory MusicInfoProvider.
+type Fake struct {
+	mu    sync.RWMutex
+	songs map[[2]string]entity.SongDetail
+	err   error
+}
+
+func NewFake(songs ...FakeSong) *Fake {
+	f := &Fake{songs: make(map[[2]string]entity.SongDetail, len(songs))}
+	for _, song := range songs {
+		f.Add(song.Group, song.Song, song.SongDetail)
+	}
+	return f
+}
+
+func LoadFake(r io.Reader) (*Fake, error) {
+	var songs []FakeSong
+	if err := json.NewDecoder(r).Decode(&songs); err != nil {
+		return nil, fmt.Errorf("failed to decode music info fixture: %w", err)
+	}
+	return NewFake(songs...), nil
+}
+
+func (f *Fake) Add(group, title string, detail entity.SongDetail) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.songs[[2]string{group, title}] = detail
+}
+
+func (f *Fake) Fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *Fake) SongInfo(ctx context.Context, group, title string) (entity.SongDetail, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.err != nil {
+		return entity.SongDetail{}, f.err
+	}
+	if group == "" || title == "" {
+		return entity.SongDetail{}, ErrBadRequest
+	}
+	detail, ok := f.songs[[2]string{group, title}]
+	if !ok {
+		return entity.SongDetail{}, ErrNotFound
+	}
+	return detail, nil
+}
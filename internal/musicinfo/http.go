@@ -0,0 +1,175 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/backoff"
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+)
+
+type HTTPProviderOptions struct {
+	AttemptTimeout time.Duration
+	Timeout        time.Duration
+	MaxAttempts    int
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+}
+
+// HTTPProvider asks the music API, retrying network errors, timeouts, 408, 429 and 5xx.
+type HTTPProvider struct {
+	url     string
+	client  *http.Client
+	options HTTPProviderOptions
+}
+
+func NewHTTPProvider(url string, options HTTPProviderOptions) *HTTPProvider {
+	return &HTTPProvider{
+		url:     url,
+		client:  &http.Client{Timeout: options.AttemptTimeout},
+		options: options,
+	}
+}
+
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+func (p *HTTPProvider) SongInfo(ctx context.Context, group, title string) (entity.SongDetail, error) {
+	params := url.Values{}
+	params.Add("group", group)
+	params.Add("song", title)
+	reqURL := p.url + "?" + params.Encode()
+
+	if p.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.options.Timeout)
+		defer cancel()
+	}
+
+	detail, err := p.lookup(ctx, reqURL)
+	lookups.WithLabelValues(lookupOutcome(err)).Inc()
+	return detail, err
+}
+
+func (p *HTTPProvider) lookup(ctx context.Context, reqURL string) (entity.SongDetail, error) {
+	for attempt := 1; ; attempt++ {
+		detail, err := p.fetch(ctx, reqURL)
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return detail, err
+		}
+		if attempt >= p.options.MaxAttempts || ctx.Err() != nil {
+			return entity.SongDetail{}, retryable.err
+		}
+
+		delay := retryable.retryAfter
+		if delay == 0 {
+			delay = backoff.Exponential(attempt, p.options.MinBackoff, p.options.MaxBackoff)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			logger.Logger.Error().Err(err).Dur("delay", delay).Msg("Music API retry would outlast the lookup timeout")
+			return entity.SongDetail{}, retryable.err
+		}
+
+		logger.Logger.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Dur("delay", delay).
+			Str("url", reqURL).
+			Msg("Retrying music API request")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return entity.SongDetail{}, retryable.err
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context, reqURL string) (entity.SongDetail, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("url", reqURL).Msg("Failed to create request to music API")
+		return entity.SongDetail{}, fmt.Errorf("failed to create request to music API: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			attempts.WithLabelValues("timeout").Inc()
+		} else {
+			attempts.WithLabelValues("error").Inc()
+		}
+		logger.Logger.Error().Err(err).Str("url", reqURL).Msg("Failed to fetch data from music API")
+		return entity.SongDetail{}, &retryableError{err: fmt.Errorf("%w: %v", ErrUnavailable, err)}
+	}
+	defer resp.Body.Close()
+	attempts.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Logger.Error().
+			Int("status", resp.StatusCode).
+			Str("url", reqURL).
+			Msg("Music API returned non-200 status")
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return entity.SongDetail{}, ErrNotFound
+		case resp.StatusCode == http.StatusBadRequest:
+			return entity.SongDetail{}, ErrBadRequest
+		case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+			return entity.SongDetail{}, &retryableError{
+				err:        fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode),
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			}
+		default:
+			return entity.SongDetail{}, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("url", reqURL).Msg("Failed to read music API response")
+		return entity.SongDetail{}, &retryableError{err: fmt.Errorf("%w: %v", ErrUnavailable, err)}
+	}
+
+	var detail entity.SongDetail
+	if err := json.Unmarshal(body, &detail); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode music API response")
+		return entity.SongDetail{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return detail, nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
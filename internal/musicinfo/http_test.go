@@ -0,0 +1,183 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+var testSong = FakeSong{
+	Group: "Muse",
+	Song:  "Supermassive Black Hole",
+	SongDetail: entity.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	},
+}
+
+var testOptions = HTTPProviderOptions{
+	AttemptTimeout: time.Second,
+	Timeout:        5 * time.Second,
+	MaxAttempts:    2,
+	MinBackoff:     time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+func TestHTTPProviderWithStub(t *testing.T) {
+	fake := NewFake(testSong)
+	server := httptest.NewServer(StubHandler(fake))
+	defer server.Close()
+	provider := NewHTTPProvider(server.URL+"/info", testOptions)
+
+	detail, err := provider.SongInfo(context.Background(), testSong.Group, testSong.Song)
+	if err != nil {
+		t.Fatalf("SongInfo() error = %v", err)
+	}
+	if detail != testSong.SongDetail {
+		t.Errorf("SongInfo() = %+v, want %+v", detail, testSong.SongDetail)
+	}
+
+	if _, err := provider.SongInfo(context.Background(), testSong.Group, "Uprising"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SongInfo() of an unknown song error = %v, want %v", err, ErrNotFound)
+	}
+
+	fake.Fail(errors.New("database is down"))
+	if _, err := provider.SongInfo(context.Background(), testSong.Group, testSong.Song); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("SongInfo() of a failing API error = %v, want %v", err, ErrUnavailable)
+	}
+}
+
+// respond answers an attempt of the retry tests.
+type respond func(w http.ResponseWriter, r *http.Request)
+
+func status(code int, header ...string) respond {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i+1 < len(header); i += 2 {
+			w.Header().Set(header[i], header[i+1])
+		}
+		w.WriteHeader(code)
+	}
+}
+
+func found(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(testSong.SongDetail)
+}
+
+// hang sends nothing until the client gives up on the request.
+func hang(w http.ResponseWriter, r *http.Request) {
+	<-r.Context().Done()
+}
+
+// stall sends a 200 and the start of the body, then hangs.
+func stall(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"releaseDate": "16.07`))
+	w.(http.Flusher).Flush()
+	<-r.Context().Done()
+}
+
+func TestHTTPProviderRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(*HTTPProviderOptions)
+		// attempts answer the requests in order; the last answers the rest.
+		attempts     []respond
+		wantErr      error
+		wantRequests int32
+		// minElapsed and maxElapsed bound how long the lookup takes.
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		{
+			name:         "5xx is retried",
+			attempts:     []respond{status(http.StatusServiceUnavailable), found},
+			wantRequests: 2,
+		},
+		{
+			name:         "429 waits for Retry-After",
+			attempts:     []respond{status(http.StatusTooManyRequests, "Retry-After", "1"), found},
+			wantRequests: 2,
+			minElapsed:   time.Second,
+		},
+		{
+			name:         "404 is not retried",
+			attempts:     []respond{status(http.StatusNotFound)},
+			wantErr:      ErrNotFound,
+			wantRequests: 1,
+		},
+		{
+			name:         "attempt timeout is retried",
+			attempts:     []respond{hang, found},
+			wantRequests: 2,
+		},
+		{
+			name:         "body read timeout is retried",
+			attempts:     []respond{stall, found},
+			wantRequests: 2,
+		},
+		{
+			name: "timeout ends the lookup",
+			options: func(o *HTTPProviderOptions) {
+				o.AttemptTimeout = 5 * time.Second
+				o.Timeout = 100 * time.Millisecond
+			},
+			attempts:     []respond{hang},
+			wantErr:      ErrUnavailable,
+			wantRequests: 1,
+			maxElapsed:   time.Second,
+		},
+		{
+			name:         "attempts are capped by MaxAttempts",
+			options:      func(o *HTTPProviderOptions) { o.MaxAttempts = 3 },
+			attempts:     []respond{status(http.StatusBadGateway)},
+			wantErr:      ErrUnavailable,
+			wantRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				tt.attempts[min(n, len(tt.attempts))-1](w, r)
+			}))
+			defer server.Close()
+
+			options := testOptions
+			options.AttemptTimeout = 100 * time.Millisecond
+			if tt.options != nil {
+				tt.options(&options)
+			}
+			provider := NewHTTPProvider(server.URL, options)
+
+			start := time.Now()
+			detail, err := provider.SongInfo(context.Background(), testSong.Group, testSong.Song)
+			elapsed := time.Since(start)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("SongInfo() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || detail != testSong.SongDetail {
+				t.Errorf("SongInfo() = %+v, %v, want %+v", detail, err, testSong.SongDetail)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("SongInfo() made %d requests, want %d", got, tt.wantRequests)
+			}
+			if elapsed < tt.minElapsed {
+				t.Errorf("SongInfo() took %v, want at least %v", elapsed, tt.minElapsed)
+			}
+			if tt.maxElapsed > 0 && elapsed > tt.maxElapsed {
+				t.Errorf("SongInfo() took %v, want at most %v", elapsed, tt.maxElapsed)
+			}
+		})
+	}
+}
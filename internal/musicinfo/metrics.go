@@ -0,0 +1,33 @@
+package musicinfo
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	attempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "song_library_music_api_attempts_total",
+		Help: "Requests made to the music API, by response status.",
+	}, []string{"result"})
+
+	lookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "song_library_music_api_lookups_total",
+		Help: "Song info lookups in the music API, by outcome.",
+	}, []string{"outcome"})
+)
+
+func lookupOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "found"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrBadRequest):
+		return "bad_request"
+	default:
+		return "failed"
+	}
+}
@@ -0,0 +1,19 @@
+// Package musicinfo looks up song details in the music API.
+package musicinfo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+var (
+	ErrNotFound    = errors.New("song not found in music API")
+	ErrBadRequest  = errors.New("music API rejected the request")
+	ErrUnavailable = errors.New("music API request failed")
+)
+
+type MusicInfoProvider interface {
+	SongInfo(ctx context.Context, group, title string) (entity.SongDetail, error)
+}
@@ -0,0 +1,41 @@
+package musicinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	logger "github.com/Zorynix/song-library/internal/logger"
+)
+
+// StubHandler serves GET /info of the music API from provider.
+func StubHandler(provider MusicInfoProvider) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /info", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		group, title := query.Get("group"), query.Get("song")
+		if group == "" || title == "" {
+			http.Error(w, "group and song are required", http.StatusBadRequest)
+			return
+		}
+
+		detail, err := provider.SongInfo(r.Context(), group, title)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, ErrBadRequest):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(detail); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to encode music info stub response")
+		}
+	})
+	return mux
+}
@@ -0,0 +1,162 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var bulkSongColumns = songColumns(entity.DefaultSongListFields)
+
+func lockSongs(ctx context.Context, tx *sqlx.Tx, selector entity.SongSelector, maxRows int) ([]int64, error) {
+	var (
+		where string
+		args  []interface{}
+	)
+	if selector.Filter != nil {
+		var err error
+		if where, args, err = buildSongFilter(*selector.Filter); err != nil {
+			logger.Logger.Error().Err(err).Msg(repoerrs.ErrBulkFailed.Error())
+			return nil, fmt.Errorf("%w: %v", repoerrs.ErrBulkFailed, err)
+		}
+		if fuzzyFilter(*selector.Filter) {
+			if err := setSimilarityThreshold(ctx, tx, selector.Filter.FuzzyThreshold); err != nil {
+				logger.Logger.Error().Err(err).Msg(repoerrs.ErrBulkFailed.Error())
+				return nil, fmt.Errorf("%w: %v", repoerrs.ErrBulkFailed, err)
+			}
+		}
+	} else {
+		where, args = ` WHERE id = ANY($1::bigint[])`, []interface{}{pq.Array(selector.IDs)}
+	}
+
+	query := `SELECT id FROM library.songs` + where + fmt.Sprintf(` ORDER BY id LIMIT $%d FOR UPDATE`, len(args)+1)
+	args = append(args, maxRows+1)
+
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids, query, args...); err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrBulkFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrBulkFailed, err)
+	}
+	if len(ids) > maxRows {
+		logger.Logger.Warn().Int("max_rows", maxRows).Msg(repoerrs.ErrTooManyRows.Error())
+		return nil, repoerrs.ErrTooManyRows
+	}
+	return ids, nil
+}
+
+// finishBulk rolls a dry run back.
+func finishBulk(tx *sqlx.Tx, dryRun bool) error {
+	if dryRun {
+		if err := tx.Rollback(); err != nil {
+			logger.Logger.Error().Err(err).Msg(repoerrs.ErrRollbackTxFailed.Error())
+			return fmt.Errorf("%w: %v", repoerrs.ErrRollbackTxFailed, err)
+		}
+		return nil
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrCommitTxFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrCommitTxFailed, err)
+	}
+	return nil
+}
+
+func (r *SongRepo) BulkDeleteSongs(ctx context.Context, selector entity.SongSelector, maxRows int, dryRun bool, newEvent entity.SongEventFunc) ([]int64, []entity.Song, error) {
+	logger.Logger.Debug().Int("ids", len(selector.IDs)).Bool("dry_run", dryRun).Msg("Bulk deleting songs")
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+	}
+	// A no-op once the transaction is finished.
+	defer tx.Rollback()
+
+	ids, err := lockSongs(ctx, tx, selector, maxRows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []songRow
+	err = tx.SelectContext(ctx, &rows, `
+		DELETE FROM library.songs
+		WHERE id = ANY($1::bigint[])
+		RETURNING `+bulkSongColumns, pq.Array(ids))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrDeleteFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrDeleteFailed, err)
+	}
+
+	songs := songsFromRows(rows)
+	if !dryRun {
+		if err := recordSongEvents(ctx, tx, newEvent, songs...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := finishBulk(tx, dryRun); err != nil {
+		return nil, nil, err
+	}
+
+	logger.Logger.Info().Int("matched", len(ids)).Int("deleted", len(songs)).Bool("dry_run", dryRun).Msg("Songs bulk deleted successfully")
+	return ids, songs, nil
+}
+
+func (r *SongRepo) BulkUpdateSongs(ctx context.Context, selector entity.SongSelector, patch entity.SongPatch, maxRows int, dryRun bool, newEvent entity.SongEventFunc) ([]int64, []entity.Song, error) {
+	logger.Logger.Debug().Int("ids", len(selector.IDs)).Bool("dry_run", dryRun).Msg("Bulk updating songs")
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+	}
+	// A no-op once the transaction is finished.
+	defer tx.Rollback()
+
+	ids, err := lockSongs(ctx, tx, selector, maxRows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tags interface{}
+	if patch.Tags != nil {
+		tags = pq.Array(*patch.Tags)
+	}
+
+	var rows []songRow
+	err = tx.SelectContext(ctx, &rows, `
+		UPDATE library.songs
+		SET "group" = COALESCE($1::text, "group"),
+			title = COALESCE($2::text, title),
+			release_date = COALESCE($3::text, release_date),
+			link = COALESCE($4::text, link),
+			tags = COALESCE($5::text[], tags)
+		WHERE id = ANY($6::bigint[])
+			AND (COALESCE($1::text, "group"), COALESCE($2::text, title), COALESCE($3::text, release_date),
+				COALESCE($4::text, link), COALESCE($5::text[], tags))
+				IS DISTINCT FROM ("group"::text, title::text, release_date::text, link::text, tags)
+		RETURNING `+bulkSongColumns,
+		patch.Group, patch.Title, patch.ReleaseDate, patch.Link, tags, pq.Array(ids))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrUpdateFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrUpdateFailed, err)
+	}
+
+	songs := songsFromRows(rows)
+	if !dryRun {
+		if err := recordSongEvents(ctx, tx, newEvent, songs...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := finishBulk(tx, dryRun); err != nil {
+		return nil, nil, err
+	}
+
+	logger.Logger.Info().Int("matched", len(ids)).Int("updated", len(songs)).Bool("dry_run", dryRun).Msg("Songs bulk updated successfully")
+	return ids, songs, nil
+}
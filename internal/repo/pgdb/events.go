@@ -0,0 +1,118 @@
+package pgdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const songEventsChannel = "song_events"
+
+// MaxNotifyPayload is the largest payload Postgres accepts in NOTIFY with the
+// default configuration.
+const MaxNotifyPayload = 7999
+
+const listenerPingInterval = 90 * time.Second
+
+type SongEventRepo struct {
+	db *sqlx.DB
+	// dsn opens the dedicated connection LISTEN needs.
+	dsn string
+}
+
+func NewSongEventRepo(db *sqlx.DB, dsn string) *SongEventRepo {
+	return &SongEventRepo{db: db, dsn: dsn}
+}
+
+// recordSongEvents runs in the writing transaction so events commit with the change.
+func recordSongEvents(ctx context.Context, tx *sqlx.Tx, newEvent entity.SongEventFunc, songs ...entity.Song) error {
+	if len(songs) == 0 {
+		return nil
+	}
+
+	events := make([]entity.SongEvent, 0, len(songs))
+	for _, song := range songs {
+		events = append(events, newEvent(song))
+	}
+	if err := enqueueSongEvents(ctx, tx, events); err != nil {
+		return err
+	}
+	return notifySongEvents(ctx, tx, events)
+}
+
+// notifySongEvents sends events in one statement. Song text is left out to
+// stay within the NOTIFY payload limit, and an event still too large goes
+// out without its song.
+func notifySongEvents(ctx context.Context, tx *sqlx.Tx, events []entity.SongEvent) error {
+	payloads := make([]string, 0, len(events))
+	for _, event := range events {
+		if event.Song != nil {
+			song := *event.Song
+			song.Text = ""
+			event.Song = &song
+		}
+		payload, err := json.Marshal(event)
+		if err == nil && len(payload) > MaxNotifyPayload {
+			logger.Logger.Warn().Str("event", event.ID).Int("size", len(payload)).Msg("Song event too large to notify, leaving out the song")
+			event.Song = nil
+			payload, err = json.Marshal(event)
+		}
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("event", event.ID).Msg(repoerrs.ErrNotifyFailed.Error())
+			return fmt.Errorf("%w: %v", repoerrs.ErrNotifyFailed, err)
+		}
+		payloads = append(payloads, string(payload))
+	}
+
+	_, err := tx.ExecContext(ctx, `SELECT pg_notify($1::text, payload) FROM unnest($2::text[]) AS payload`, songEventsChannel, pq.Array(payloads))
+	if err != nil {
+		logger.Logger.Error().Err(err).Int("events", len(events)).Msg(repoerrs.ErrNotifyFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrNotifyFailed, err)
+	}
+
+	logger.Logger.Debug().Int("events", len(events)).Msg("Song events notified")
+	return nil
+}
+
+// ListenSongEvents calls handle with a nil payload when notifications may have been lost.
+func (r *SongEventRepo) ListenSongEvents(ctx context.Context, handle func(payload []byte)) error {
+	listener := pq.NewListener(r.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Logger.Warn().Err(err).Int("event", int(event)).Msg("Song event listener connection problem")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(songEventsChannel); err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrListenFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrListenFailed, err)
+	}
+	logger.Logger.Info().Str("channel", songEventsChannel).Msg("Listening for song events")
+
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			if notification == nil {
+				logger.Logger.Warn().Msg("Song event listener reconnected")
+				handle(nil)
+				continue
+			}
+			handle([]byte(notification.Extra))
+		case <-ping.C:
+			// Ping makes the listener notice a dead connection.
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package pgdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zorynix/song-library/internal/filterql"
+)
+
+// releaseDateExpr is NULL for a release_date not in DD.MM.YYYY.
+const releaseDateExpr = `(CASE WHEN release_date ~ '^\d{2}\.\d{2}\.\d{4}$' THEN to_date(release_date, 'DD.MM.YYYY') END)`
+
+var comparisonOperators = map[filterql.Op]string{
+	filterql.OpEq: "=",
+	filterql.OpNe: "<>",
+	filterql.OpGt: ">",
+	filterql.OpGe: ">=",
+	filterql.OpLt: "<",
+	filterql.OpLe: "<=",
+}
+
+type filterCompiler struct {
+	args []interface{}
+}
+
+func (c *filterCompiler) compile(expr filterql.Expr) string {
+	switch e := expr.(type) {
+	case filterql.And:
+		return "(" + c.compile(e.Left) + " AND " + c.compile(e.Right) + ")"
+	case filterql.Or:
+		return "(" + c.compile(e.Left) + " OR " + c.compile(e.Right) + ")"
+	case filterql.Not:
+		return "NOT " + c.compile(e.Expr)
+	case filterql.Comparison:
+		return c.comparison(e)
+	default:
+		panic(fmt.Sprintf("pgdb: unsupported filter expression %T", expr))
+	}
+}
+
+func (c *filterCompiler) comparison(cmp filterql.Comparison) string {
+	fieldType := filterql.Fields[cmp.Field]
+	column := songFieldColumns[cmp.Field]
+	if fieldType == filterql.TypeDate {
+		column = releaseDateExpr
+	}
+
+	switch cmp.Op {
+	case filterql.OpContains:
+		return column + " ILIKE " + c.arg("%"+escapeLike(cmp.Values[0].String)+"%")
+	case filterql.OpStartsWith:
+		return column + " ILIKE " + c.arg(escapeLike(cmp.Values[0].String)+"%")
+	case filterql.OpIn:
+		placeholders := make([]string, 0, len(cmp.Values))
+		for _, value := range cmp.Values {
+			placeholders = append(placeholders, c.value(fieldType, value))
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+	default:
+		return column + " " + comparisonOperators[cmp.Op] + " " + c.value(fieldType, cmp.Values[0])
+	}
+}
+
+func (c *filterCompiler) arg(value interface{}) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *filterCompiler) value(fieldType filterql.FieldType, value filterql.Value) string {
+	switch fieldType {
+	case filterql.TypeNumber:
+		return c.arg(value.Number)
+	case filterql.TypeDate:
+		return c.arg(value.Date.Format("2006-01-02")) + "::date"
+	default:
+		return c.arg(value.String)
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
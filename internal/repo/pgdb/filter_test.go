@@ -0,0 +1,49 @@
+package pgdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Zorynix/song-library/internal/filterql"
+)
+
+func TestFilterCompiler(t *testing.T) {
+	tests := []struct {
+		input    string
+		prefixed int
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			input:    "group eq 'Muse'",
+			want:     `"group" = $1`,
+			wantArgs: []interface{}{"Muse"},
+		},
+		{
+			input:    "title contains '50%_off' and not id in (1, 2)",
+			prefixed: 2,
+			want:     `(title ILIKE $3 AND NOT id IN ($4, $5))`,
+			wantArgs: []interface{}{`%50\%\_off%`, int64(1), int64(2)},
+		},
+		{
+			input:    "link startswith 'https://' or releaseDate ge '16.07.2006'",
+			want:     `(link ILIKE $1 OR ` + releaseDateExpr + ` >= $2::date)`,
+			wantArgs: []interface{}{"https://%", "2006-07-16"},
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := filterql.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.input, err)
+		}
+
+		c := &filterCompiler{args: make([]interface{}, tt.prefixed)}
+		if got := c.compile(expr); got != tt.want {
+			t.Errorf("compile(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+		if got := c.args[tt.prefixed:]; !reflect.DeepEqual(got, tt.wantArgs) {
+			t.Errorf("compile(%q) args = %#v, want %#v", tt.input, got, tt.wantArgs)
+		}
+	}
+}
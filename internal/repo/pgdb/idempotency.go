@@ -0,0 +1,111 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepo(db *sqlx.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+// Reserve stores a pending record for key, leased until lockedUntil. It takes
+// over an expired record and a pending one of the same request whose lease
+// ran out; otherwise the stored record is returned with created false.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, key, requestHash string, lockedUntil, expiresAt time.Time) (entity.IdempotencyRecord, bool, error) {
+	logger.Logger.Debug().Str("key", key).Msg("Reserving idempotency key")
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO library.idempotency_keys (key, request_hash, locked_until, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = excluded.request_hash, status_code = NULL, content_type = '', response_body = NULL,
+			locked_until = excluded.locked_until, created_at = now(), expires_at = excluded.expires_at
+		WHERE idempotency_keys.expires_at < now()
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until < now()
+				AND idempotency_keys.request_hash = excluded.request_hash)`, key, requestHash, lockedUntil, expiresAt)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg(repoerrs.ErrIdempotencyFailed.Error())
+		return entity.IdempotencyRecord{}, false, fmt.Errorf("%w: %v", repoerrs.ErrIdempotencyFailed, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg(repoerrs.ErrRowsAffectedFailed.Error())
+		return entity.IdempotencyRecord{}, false, fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
+	}
+	if rows == 1 {
+		logger.Logger.Info().Str("key", key).Msg("Idempotency key reserved")
+		return entity.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}, true, nil
+	}
+
+	var record entity.IdempotencyRecord
+	err = r.db.GetContext(ctx, &record, `
+		SELECT key, request_hash, status_code, content_type, response_body, expires_at
+		FROM library.idempotency_keys WHERE key = $1`, key)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg(repoerrs.ErrIdempotencyFailed.Error())
+		return entity.IdempotencyRecord{}, false, fmt.Errorf("%w: %v", repoerrs.ErrIdempotencyFailed, err)
+	}
+
+	logger.Logger.Info().Str("key", key).Msg("Idempotency key already exists")
+	return record, false, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	logger.Logger.Debug().Str("key", key).Int("status", statusCode).Msg("Storing idempotent response")
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE library.idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3, locked_until = NULL
+		WHERE key = $4`, statusCode, contentType, body, key)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg(repoerrs.ErrIdempotencyFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrIdempotencyFailed, err)
+	}
+
+	logger.Logger.Info().Str("key", key).Msg("Idempotent response stored successfully")
+	return nil
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	logger.Logger.Debug().Str("key", key).Msg("Releasing idempotency key")
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM library.idempotency_keys WHERE key = $1 AND status_code IS NULL`, key)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg(repoerrs.ErrIdempotencyFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrIdempotencyFailed, err)
+	}
+
+	logger.Logger.Info().Str("key", key).Msg("Idempotency key released")
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	logger.Logger.Debug().Msg("Deleting expired idempotency keys")
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM library.idempotency_keys WHERE expires_at < now()`)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrIdempotencyFailed.Error())
+		return 0, fmt.Errorf("%w: %v", repoerrs.ErrIdempotencyFailed, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrRowsAffectedFailed.Error())
+		return 0, fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
+	}
+
+	logger.Logger.Info().Int64("count", rows).Msg("Expired idempotency keys deleted")
+	return rows, nil
+}
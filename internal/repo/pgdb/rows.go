@@ -0,0 +1,60 @@
+package pgdb
+
+import (
+	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/lib/pq"
+)
+
+// lib/pq only scans text[] into its own array types, so these rows shadow the
+// list fields of the embedded entities.
+
+type songRow struct {
+	entity.Song
+	Tags pq.StringArray `db:"tags"`
+}
+
+func (row songRow) song() entity.Song {
+	song := row.Song
+	song.Tags = row.Tags
+	return song
+}
+
+func songsFromRows(rows []songRow) []entity.Song {
+	songs := make([]entity.Song, 0, len(rows))
+	for _, row := range rows {
+		songs = append(songs, row.song())
+	}
+	return songs
+}
+
+type similarSongRow struct {
+	entity.SimilarSong
+	Tags pq.StringArray `db:"tags"`
+}
+
+type webhookRow struct {
+	entity.Webhook
+	Events pq.StringArray `db:"events"`
+}
+
+func (row webhookRow) webhook() entity.Webhook {
+	webhook := row.Webhook
+	webhook.Events = row.Events
+	return webhook
+}
+
+type lyricVersesRow struct {
+	ID           int64          `db:"id"`
+	Group        string         `db:"group"`
+	Title        string         `db:"title"`
+	VerseIndexes pq.Int64Array  `db:"verse_indexes"`
+	Verses       pq.StringArray `db:"verses"`
+}
+
+func (row lyricVersesRow) lyricVerses() entity.LyricVerses {
+	match := entity.LyricVerses{SongID: row.ID, Group: row.Group, Title: row.Title, Verses: make([]entity.Verse, 0, len(row.Verses))}
+	for i, text := range row.Verses {
+		match.Verses = append(match.Verses, entity.Verse{Index: int(row.VerseIndexes[i]), Text: text})
+	}
+	return match
+}
@@ -2,13 +2,21 @@ package pgdb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"iter"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/Zorynix/song-library/internal/filterql"
 	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/lyrics"
 	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type SongRepo struct {
@@ -24,30 +32,20 @@ func (r *SongRepo) GetSongs(ctx context.Context, filter entity.SongFilter) ([]en
 		Str("group", filter.Group).
 		Str("title", filter.Title).
 		Str("text", filter.Text).
+		Str("q", filter.Query).
 		Int("limit", filter.Limit).
 		Int("offset", filter.Offset).
 		Msg("Fetching songs with filter")
 
-	var songs []entity.Song
-	query := `SELECT id, "group", title, release_date, text, link FROM library.songs WHERE 1=1`
-	var args []interface{}
-	argIndex := 1
-
-	if filter.Group != "" {
-		query += fmt.Sprintf(" AND \"group\" ILIKE $%d", argIndex)
-		args = append(args, "%"+filter.Group+"%")
-		argIndex++
-	}
-	if filter.Title != "" {
-		query += fmt.Sprintf(" AND title ILIKE $%d", argIndex)
-		args = append(args, "%"+filter.Title+"%")
-		argIndex++
-	}
-	if filter.Text != "" {
-		query += fmt.Sprintf(" AND text ILIKE $%d", argIndex)
-		args = append(args, "%"+filter.Text+"%")
-		argIndex++
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
 	}
+	from, args := songSource(filter, args)
+	order, args := songOrder(filter, args)
+	query := `SELECT ` + songColumns(filter.Fields) + from + where + order
+	argIndex := len(args) + 1
 
 	if filter.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT $%d", argIndex)
@@ -59,65 +57,375 @@ func (r *SongRepo) GetSongs(ctx context.Context, filter entity.SongFilter) ([]en
 		args = append(args, filter.Offset)
 	}
 
-	err := r.db.SelectContext(ctx, &songs, query, args...)
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	var rows []songRow
+	err = sqlx.SelectContext(ctx, db, &rows, query, args...)
 	if err != nil {
 		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
 		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
 	}
 
-	logger.Logger.Info().Int("count", len(songs)).Msg("Songs fetched successfully")
-	return songs, nil
+	logger.Logger.Info().Int("count", len(rows)).Msg("Songs fetched successfully")
+	return songsFromRows(rows), nil
+}
+
+func (r *SongRepo) CountSongs(ctx context.Context, filter entity.SongFilter) (int, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Msg("Counting songs with filter")
+
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrCountSongsFailed.Error())
+		return 0, fmt.Errorf("%w: %v", repoerrs.ErrCountSongsFailed, err)
+	}
+	query := `SELECT COUNT(*) FROM library.songs` + where
+
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	var total int
+	err = sqlx.GetContext(ctx, db, &total, query, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrCountSongsFailed.Error())
+		return 0, fmt.Errorf("%w: %v", repoerrs.ErrCountSongsFailed, err)
+	}
+
+	logger.Logger.Info().Int("total", total).Msg("Songs counted successfully")
+	return total, nil
+}
+
+// PickSong seeks into the id range when unfiltered, so songs after id gaps are likelier.
+func (r *SongRepo) PickSong(ctx context.Context, filter entity.SongFilter, position float64) (entity.Song, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Float64("position", position).
+		Msg("Picking song")
+
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+	query := `SELECT ` + songColumns(nil) + ` FROM library.songs
+		WHERE id >= (SELECT min(id) + floor($1::float8 * (max(id) - min(id) + 1))::bigint FROM library.songs)
+		ORDER BY id
+		LIMIT 1`
+	if !unfilteredSongs(filter) {
+		query = `SELECT ` + songColumns(nil) + ` FROM library.songs` + where + fmt.Sprintf(`
+		ORDER BY id
+		OFFSET floor($%d::float8 * (SELECT COUNT(*) FROM library.songs`+where+`))::bigint
+		LIMIT 1`, len(args)+1)
+	}
+
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return entity.Song{}, err
+	}
+	defer done()
+
+	var row songRow
+	err = sqlx.GetContext(ctx, db, &row, query, append(args, position)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Msg(repoerrs.ErrNotFound.Error())
+		return entity.Song{}, repoerrs.ErrNotFound
+	}
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+
+	logger.Logger.Info().Int64("id", row.ID).Msg("Song picked successfully")
+	return row.song(), nil
+}
+
+func unfilteredSongs(filter entity.SongFilter) bool {
+	return filter.Group == "" && filter.Title == "" && filter.Text == "" && filter.Query == "" &&
+		filter.Language == "" && filter.Filter == ""
+}
+
+var songFieldColumns = map[string]string{
+	"id":          "id",
+	"group":       `"group"`,
+	"title":       "title",
+	"releaseDate": "release_date",
+	"text":        "text",
+	"link":        "link",
+	"tags":        "tags",
+	"language":    "language",
+}
+
+// songColumns always selects the id; an empty fieldset selects every column.
+func songColumns(fields []string) string {
+	if len(fields) == 0 {
+		fields = entity.SongFields
+	}
+
+	columns := []string{"id"}
+	for _, field := range fields {
+		if column, ok := songFieldColumns[field]; ok && !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// fuzzyCondition matches at pg_trgm.similarity_threshold, so the query has to
+// run in a songReader.
+func fuzzyCondition(column string, argIndex int) string {
+	return fmt.Sprintf(" AND %s %% $%d::text", column, argIndex)
+}
+
+func fuzzyFilter(filter entity.SongFilter) bool {
+	return filter.Fuzzy && (filter.Group != "" || filter.Title != "")
+}
+
+func setSimilarityThreshold(ctx context.Context, tx *sqlx.Tx, threshold float64) error {
+	_, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1::text, true)`,
+		strconv.FormatFloat(threshold, 'f', -1, 64))
+	return err
+}
+
+func similarityReader(ctx context.Context, db *sqlx.DB, threshold float64) (sqlx.QueryerContext, func(), error) {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+	}
+	if err := setSimilarityThreshold(ctx, tx, threshold); err != nil {
+		tx.Rollback()
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
+		return nil, nil, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+	}
+	return tx, func() { tx.Rollback() }, nil
+}
+
+// songReader returns a read-only transaction with the similarity threshold
+// set for a fuzzy filter, the database otherwise.
+func songReader(ctx context.Context, db *sqlx.DB, filter entity.SongFilter) (sqlx.QueryerContext, func(), error) {
+	if !fuzzyFilter(filter) {
+		return db, func() {}, nil
+	}
+	return similarityReader(ctx, db, filter.FuzzyThreshold)
+}
+
+func songOrder(filter entity.SongFilter, args []interface{}) (string, []interface{}) {
+	if fuzzyFilter(filter) {
+		var terms []string
+		if filter.Group != "" {
+			args = append(args, filter.Group)
+			terms = append(terms, fmt.Sprintf(`similarity("group", $%d::text)`, len(args)))
+		}
+		if filter.Title != "" {
+			args = append(args, filter.Title)
+			terms = append(terms, fmt.Sprintf(`similarity(title, $%d::text)`, len(args)))
+		}
+		return ` ORDER BY ` + strings.Join(terms, " + ") + ` DESC, id`, args
+	}
+	if filter.Query != "" {
+		return ` ORDER BY hit.rank`, args
+	}
+	return ` ORDER BY id`, args
+}
+
+// songSource joins the search hits of a full-text query for songOrder.
+func songSource(filter entity.SongFilter, args []interface{}) (string, []interface{}) {
+	if filter.Query == "" || fuzzyFilter(filter) {
+		return ` FROM library.songs`, args
+	}
+	args = append(args, pq.Array(filter.SearchIDs))
+	return fmt.Sprintf(` FROM library.songs JOIN unnest($%d::bigint[]) WITH ORDINALITY AS hit(id, rank) USING (id)`, len(args)), args
+}
+
+func buildSongFilter(filter entity.SongFilter) (string, []interface{}, error) {
+	where := ` WHERE 1=1`
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Group != "" {
+		if filter.Fuzzy {
+			where += fuzzyCondition(`"group"`, argIndex)
+			args = append(args, filter.Group)
+			argIndex++
+		} else {
+			where += fmt.Sprintf(" AND \"group\" ILIKE $%d", argIndex)
+			args = append(args, "%"+filter.Group+"%")
+			argIndex++
+		}
+	}
+	if filter.Title != "" {
+		if filter.Fuzzy {
+			where += fuzzyCondition("title", argIndex)
+			args = append(args, filter.Title)
+			argIndex++
+		} else {
+			where += fmt.Sprintf(" AND title ILIKE $%d", argIndex)
+			args = append(args, "%"+filter.Title+"%")
+			argIndex++
+		}
+	}
+	if filter.Text != "" {
+		where += fmt.Sprintf(" AND text ILIKE $%d", argIndex)
+		args = append(args, "%"+filter.Text+"%")
+		argIndex++
+	}
+	if filter.Query != "" {
+		where += fmt.Sprintf(" AND id = ANY($%d::bigint[])", argIndex)
+		args = append(args, pq.Array(filter.SearchIDs))
+		argIndex++
+	}
+	if filter.Language != "" {
+		where += fmt.Sprintf(" AND language = $%d", argIndex)
+		args = append(args, filter.Language)
+	}
+	if filter.Filter != "" {
+		expr, err := filterql.Parse(filter.Filter)
+		if err != nil {
+			return "", nil, err
+		}
+		compiler := &filterCompiler{args: args}
+		where += " AND " + compiler.compile(expr)
+		args = compiler.args
+	}
+
+	return where, args, nil
 }
 
-func (r *SongRepo) GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]string, error) {
+func (r *SongRepo) GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]entity.Verse, int, error) {
 	logger.Logger.Debug().
 		Int64("song_id", pagination.SongID).
 		Int("limit", pagination.Limit).
 		Int("offset", pagination.Offset).
+		Str("unit", string(pagination.Unit)).
 		Msg("Fetching song verses")
 
 	var text string
 	err := r.db.GetContext(ctx, &text, `SELECT text FROM library.songs WHERE id = $1`, pagination.SongID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("song_id", pagination.SongID).Msg(repoerrs.ErrNotFound.Error())
+		return nil, 0, repoerrs.ErrNotFound
+	}
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("song_id", pagination.SongID).Msg(repoerrs.ErrFetchVersesFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrFetchVersesFailed, err)
+	}
+
+	verses := lyrics.Verses(text)
+	if pagination.Unit == entity.VerseUnitLine {
+		verses = lyrics.Lines(text)
+	}
+	if pagination.Offset >= len(verses) {
+		logger.Logger.Warn().Int64("song_id", pagination.SongID).Int("offset", pagination.Offset).Msg("Offset exceeds verses count")
+		return []entity.Verse{}, len(verses), nil
+	}
+
+	page := lyrics.Page(verses, pagination.Limit, pagination.Offset)
+	result := make([]entity.Verse, 0, len(page))
+	for i, verse := range page {
+		result = append(result, entity.Verse{Index: pagination.Offset + i, Text: verse})
+	}
+
+	logger.Logger.Info().
+		Int64("song_id", pagination.SongID).
+		Int("verse_count", len(result)).
+		Msg("Song verses fetched successfully")
+	return result, len(verses), nil
+}
+
+func (r *SongRepo) GetSongTexts(ctx context.Context, ids []int64) (map[int64]string, error) {
+	logger.Logger.Debug().Ints64("ids", ids).Msg("Fetching song texts")
+
+	var rows []struct {
+		ID   int64  `db:"id"`
+		Text string `db:"text"`
+	}
+	err := r.db.SelectContext(ctx, &rows, `SELECT id, text FROM library.songs WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchVersesFailed.Error())
 		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchVersesFailed, err)
 	}
 
-	if text == "" {
-		logger.Logger.Info().Int64("song_id", pagination.SongID).Msg("Song text is empty")
-		return []string{}, nil
+	texts := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		texts[row.ID] = row.Text
 	}
 
-	verses := strings.Split(text, "\n\n")
-	if len(verses) == 0 {
-		verses = strings.Split(text, "\n")
+	logger.Logger.Info().Int("count", len(texts)).Msg("Song texts fetched successfully")
+	return texts, nil
+}
+
+// lyricVerses must split text the way lyrics.Verses does.
+const lyricVerses = `
+	FROM library.songs s
+	CROSS JOIN LATERAL (
+		SELECT array_agg(v.i - 1 ORDER BY v.i) AS verse_indexes, array_agg(v.verse ORDER BY v.i) AS verses
+		FROM unnest(string_to_array(s.text, E'\n\n')) WITH ORDINALITY AS v(verse, i)
+		WHERE strpos(lower(v.verse), lower($2::text)) > 0
+	) m
+	WHERE s.text ILIKE $1::text AND m.verse_indexes IS NOT NULL`
+
+func (r *SongRepo) SearchLyrics(ctx context.Context, search entity.LyricSearch) ([]entity.LyricVerses, int, error) {
+	logger.Logger.Debug().
+		Str("query", search.Query).
+		Int("limit", search.Limit).
+		Int("offset", search.Offset).
+		Msg("Searching lyrics")
+
+	pattern := "%" + escapeLike(search.Query) + "%"
+
+	var total int
+	err := r.db.GetContext(ctx, &total, `SELECT COUNT(*)`+lyricVerses, pattern, search.Query)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrCountSongsFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrCountSongsFailed, err)
 	}
 
-	start := pagination.Offset
-	if start >= len(verses) {
-		logger.Logger.Warn().Int64("song_id", pagination.SongID).Int("offset", start).Msg("Offset exceeds verses count")
-		return []string{}, nil
+	query := `SELECT s.id, s."group", s.title, m.verse_indexes, m.verses` + lyricVerses + ` ORDER BY s.id`
+	args := []interface{}{pattern, search.Query}
+	if search.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, search.Limit)
+	}
+	if search.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+		args = append(args, search.Offset)
 	}
 
-	end := start + pagination.Limit
-	if pagination.Limit <= 0 || end > len(verses) {
-		end = len(verses)
+	var rows []lyricVersesRow
+	err = r.db.SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
 	}
 
-	logger.Logger.Info().
-		Int64("song_id", pagination.SongID).
-		Int("verse_count", len(verses[start:end])).
-		Msg("Song verses fetched successfully")
-	return verses[start:end], nil
+	matches := make([]entity.LyricVerses, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, row.lyricVerses())
+	}
+
+	logger.Logger.Info().Int("count", len(matches)).Int("total", total).Msg("Lyrics searched successfully")
+	return matches, total, nil
 }
 
-func (r *SongRepo) DeleteSong(ctx context.Context, id int64) error {
+func (r *SongRepo) DeleteSong(ctx context.Context, id int64, newEvent entity.SongEventFunc) (entity.Song, error) {
 	logger.Logger.Debug().Int64("id", id).Msg("Deleting song")
 
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
 	}
 
 	defer func() {
@@ -134,27 +442,27 @@ func (r *SongRepo) DeleteSong(ctx context.Context, id int64) error {
 		}
 	}()
 
-	result, err := tx.ExecContext(ctx, `DELETE FROM library.songs WHERE id = $1`, id)
+	var row songRow
+	err = tx.GetContext(ctx, &row, `DELETE FROM library.songs WHERE id = $1 RETURNING `+songColumns(nil), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("id", id).Msg(repoerrs.ErrNotFound.Error())
+		return entity.Song{}, repoerrs.ErrNotFound
+	}
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrDeleteFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrDeleteFailed, err)
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrDeleteFailed, err)
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrRowsAffectedFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
-	}
-	if rows == 0 {
-		logger.Logger.Warn().Int64("id", id).Msg(repoerrs.ErrNotFound.Error())
-		return repoerrs.ErrNotFound
+	deleted := row.song()
+	if err = recordSongEvents(ctx, tx, newEvent, deleted); err != nil {
+		return entity.Song{}, err
 	}
 
 	logger.Logger.Info().Int64("id", id).Msg("Song deleted successfully")
-	return nil
+	return deleted, nil
 }
 
-func (r *SongRepo) UpdateSong(ctx context.Context, song entity.Song) error {
+func (r *SongRepo) UpdateSong(ctx context.Context, song entity.Song, newEvent entity.SongEventFunc) (entity.Song, error) {
 	logger.Logger.Debug().
 		Int64("id", song.ID).
 		Str("group", song.Group).
@@ -164,7 +472,7 @@ func (r *SongRepo) UpdateSong(ctx context.Context, song entity.Song) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
 	}
 
 	defer func() {
@@ -181,31 +489,31 @@ func (r *SongRepo) UpdateSong(ctx context.Context, song entity.Song) error {
 		}
 	}()
 
-	query := `
-		UPDATE library.songs 
-		SET "group" = $1, title = $2, release_date = $3, text = $4, link = $5 
-		WHERE id = $6`
-	result, err := tx.ExecContext(ctx, query, song.Group, song.Title, song.ReleaseDate, song.Text, song.Link, song.ID)
+	var row songRow
+	err = tx.GetContext(ctx, &row, `
+		UPDATE library.songs
+		SET "group" = $1, title = $2, release_date = $3, text = $4, link = $5, tags = COALESCE($6::text[], '{}'), language = $7
+		WHERE id = $8
+		RETURNING `+songColumns(nil), song.Group, song.Title, song.ReleaseDate, song.Text, song.Link, pq.Array(song.Tags), song.Language, song.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("id", song.ID).Msg(repoerrs.ErrNotFound.Error())
+		return entity.Song{}, repoerrs.ErrNotFound
+	}
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", song.ID).Msg(repoerrs.ErrUpdateFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrUpdateFailed, err)
+		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrUpdateFailed, err)
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		logger.Logger.Error().Err(err).Int64("id", song.ID).Msg(repoerrs.ErrRowsAffectedFailed.Error())
-		return fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
-	}
-	if rows == 0 {
-		logger.Logger.Warn().Int64("id", song.ID).Msg(repoerrs.ErrNotFound.Error())
-		return repoerrs.ErrNotFound
+	updated := row.song()
+	if err = recordSongEvents(ctx, tx, newEvent, updated); err != nil {
+		return entity.Song{}, err
 	}
 
 	logger.Logger.Info().Int64("id", song.ID).Msg("Song updated successfully")
-	return nil
+	return updated, nil
 }
 
-func (r *SongRepo) AddSong(ctx context.Context, song entity.Song) (entity.Song, error) {
+func (r *SongRepo) AddSong(ctx context.Context, song entity.Song, newEvent entity.SongEventFunc) (entity.Song, error) {
 	logger.Logger.Debug().
 		Str("group", song.Group).
 		Str("title", song.Title).
@@ -232,16 +540,256 @@ func (r *SongRepo) AddSong(ctx context.Context, song entity.Song) (entity.Song,
 	}()
 
 	query := `
-		INSERT INTO library.songs ("group", title, release_date, text, link) 
-		VALUES ($1, $2, $3, $4, $5) 
-		RETURNING id, "group", title, release_date, text, link`
-	var createdSong entity.Song
-	err = tx.GetContext(ctx, &createdSong, query, song.Group, song.Title, song.ReleaseDate, song.Text, song.Link)
+		INSERT INTO library.songs ("group", title, release_date, text, link, tags, language) 
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::text[], '{}'), $7) 
+		RETURNING id, "group", title, release_date, text, link, tags, language`
+	var created songRow
+	err = tx.GetContext(ctx, &created, query, song.Group, song.Title, song.ReleaseDate, song.Text, song.Link, pq.Array(song.Tags), song.Language)
 	if err != nil {
 		logger.Logger.Error().Err(err).Str("group", song.Group).Str("title", song.Title).Msg(repoerrs.ErrInsertFailed.Error())
 		return entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrInsertFailed, err)
 	}
 
-	logger.Logger.Info().Int64("id", createdSong.ID).Msg("Song added successfully")
-	return createdSong, nil
+	if err = recordSongEvents(ctx, tx, newEvent, created.song()); err != nil {
+		return entity.Song{}, err
+	}
+
+	logger.Logger.Info().Int64("id", created.ID).Msg("Song added successfully")
+	return created.song(), nil
+}
+
+var nameColumns = map[string]string{
+	"group": `"group"`,
+	"title": "title",
+}
+
+func (r *SongRepo) SuggestSongNames(ctx context.Context, field, value string, threshold float64, limit int) ([]entity.Suggestion, error) {
+	logger.Logger.Debug().Str("field", field).Str("value", value).Msg("Fetching song name suggestions")
+
+	column, ok := nameColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %q", repoerrs.ErrFetchSongsFailed, field)
+	}
+
+	db, done, err := similarityReader(ctx, r.db, threshold)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	var suggestions []entity.Suggestion
+	err = sqlx.SelectContext(ctx, db, &suggestions, `
+		SELECT `+column+` AS value, max(similarity(`+column+`, $1::text)) AS score
+		FROM library.songs
+		WHERE `+column+` % $1::text
+		GROUP BY `+column+`
+		ORDER BY score DESC, count(*) DESC, value
+		LIMIT $2`, value, limit)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("field", field).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+
+	for i := range suggestions {
+		suggestions[i].Field = field
+	}
+	logger.Logger.Info().Int("count", len(suggestions)).Str("field", field).Msg("Song name suggestions fetched successfully")
+	return suggestions, nil
+}
+
+func (r *SongRepo) CompleteSongNames(ctx context.Context, completion entity.NameCompletion) ([]entity.Completion, error) {
+	logger.Logger.Debug().
+		Str("field", completion.Field).
+		Str("prefix", completion.Prefix).
+		Int("limit", completion.Limit).
+		Msg("Fetching song name completions")
+
+	column, ok := nameColumns[completion.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %q", repoerrs.ErrFetchSongsFailed, completion.Field)
+	}
+
+	completions := []entity.Completion{}
+	err := r.db.SelectContext(ctx, &completions, `
+		SELECT `+column+` AS value, count(*) AS songs
+		FROM library.songs
+		WHERE lower(`+column+`) LIKE lower($1::text)
+		GROUP BY `+column+`
+		ORDER BY songs DESC, value
+		LIMIT $2`, escapeLike(completion.Prefix)+"%", completion.Limit)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("field", completion.Field).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+
+	logger.Logger.Info().Int("count", len(completions)).Str("field", completion.Field).Msg("Song name completions fetched successfully")
+	return completions, nil
+}
+
+const scanBatch = 500
+
+func (r *SongRepo) ScanSongs(ctx context.Context, filter entity.SongFilter) iter.Seq2[entity.Song, error] {
+	return func(yield func(entity.Song, error) bool) {
+		logger.Logger.Debug().
+			Str("group", filter.Group).
+			Str("title", filter.Title).
+			Str("text", filter.Text).
+			Str("q", filter.Query).
+			Int("limit", filter.Limit).
+			Int("offset", filter.Offset).
+			Msg("Scanning songs with filter")
+
+		where, args, err := buildSongFilter(filter)
+		if err != nil {
+			logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchSongsFailed.Error())
+			yield(entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err))
+			return
+		}
+		query := `SELECT ` + songColumns(filter.Fields) + ` FROM library.songs` + where +
+			fmt.Sprintf(` AND id > $%d ORDER BY id LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2, len(args)+3)
+
+		var after int64
+		offset := filter.Offset
+		read := 0
+		for {
+			batch := scanBatch
+			if filter.Limit > 0 {
+				batch = min(batch, filter.Limit-read)
+			}
+
+			rows, err := r.readBatch(ctx, filter, query, append(args, after, batch, offset))
+			if err != nil {
+				logger.Logger.Error().Err(err).Int64("after", after).Msg(repoerrs.ErrFetchSongsFailed.Error())
+				yield(entity.Song{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err))
+				return
+			}
+			for _, row := range rows {
+				if !yield(row.song(), nil) {
+					return
+				}
+			}
+			read += len(rows)
+			if len(rows) < batch || (filter.Limit > 0 && read >= filter.Limit) {
+				logger.Logger.Info().Int("count", read).Msg("Songs scanned successfully")
+				return
+			}
+			after = rows[len(rows)-1].ID
+			offset = 0
+		}
+	}
+}
+
+func (r *SongRepo) readBatch(ctx context.Context, filter entity.SongFilter, query string, args []interface{}) ([]songRow, error) {
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	var rows []songRow
+	err = sqlx.SelectContext(ctx, db, &rows, query, args...)
+	return rows, err
+}
+
+func (r *SongRepo) AllSongs(ctx context.Context) iter.Seq2[entity.Song, error] {
+	return r.ScanSongs(ctx, entity.SongFilter{Fields: []string{"group", "title", "text", "language"}})
+}
+
+const (
+	similarTerms      = 25
+	similarGroupBoost = 0.5
+	similarTagBoost   = 0.25
+)
+
+// SimilarSongs ranks songs by cosine similarity of tf-idf lyric vectors.
+func (r *SongRepo) SimilarSongs(ctx context.Context, query entity.SimilarQuery) ([]entity.SimilarSong, error) {
+	logger.Logger.Debug().
+		Int64("song_id", query.SongID).
+		Int("limit", query.Limit).
+		Bool("boost_group", query.BoostGroup).
+		Bool("boost_tags", query.BoostTags).
+		Msg("Fetching similar songs")
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM library.songs WHERE id = $1)`, query.SongID)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", query.SongID).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+	if !exists {
+		logger.Logger.Warn().Int64("song_id", query.SongID).Msg(repoerrs.ErrNotFound.Error())
+		return nil, repoerrs.ErrNotFound
+	}
+
+	// Words newer than the last refresh of song_terms count as in one song.
+	var rows []similarSongRow
+	err = r.db.SelectContext(ctx, &rows, `
+		WITH corpus AS (
+			SELECT count(*)::float8 AS songs FROM library.song_vectors
+		),
+		target AS (
+			SELECT v.term, v.weight * ln(1 + corpus.songs / coalesce(t.songs, 1)) AS tfidf
+			FROM library.song_vectors s
+			CROSS JOIN LATERAL unnest(s.terms, s.weights) AS v(term, weight)
+			LEFT JOIN library.song_terms t ON t.term = v.term
+			CROSS JOIN corpus
+			WHERE s.song_id = $1
+		),
+		distinctive AS (
+			SELECT term FROM target ORDER BY tfidf DESC, term LIMIT $2
+		),
+		matches AS (
+			SELECT c.song_id,
+				sum(target.tfidf * w.tfidf) AS dot,
+				sqrt(sum(w.tfidf ^ 2)) AS norm
+			FROM library.song_vectors c
+			CROSS JOIN corpus
+			CROSS JOIN LATERAL (
+				SELECT v.term, v.weight * ln(1 + corpus.songs / coalesce(t.songs, 1)) AS tfidf
+				FROM unnest(c.terms, c.weights) AS v(term, weight)
+				LEFT JOIN library.song_terms t ON t.term = v.term
+			) AS w
+			LEFT JOIN target ON target.term = w.term
+			WHERE c.terms && ARRAY(SELECT term FROM distinctive) AND c.song_id <> $1
+			GROUP BY c.song_id
+		)
+		SELECT s.id, s."group", s.title, s.release_date, s.link, s.tags,
+			m.dot / (sqrt((SELECT sum(tfidf ^ 2) FROM target)) * m.norm)
+				* (1
+					+ CASE WHEN $3::boolean AND s."group" = me."group" THEN $5::float8 ELSE 0 END
+					+ CASE WHEN $4::boolean
+						THEN $6::float8 * cardinality(ARRAY(SELECT unnest(s.tags) INTERSECT SELECT unnest(me.tags)))
+						ELSE 0 END) AS score
+		FROM matches m
+		JOIN library.songs s ON s.id = m.song_id
+		JOIN library.songs me ON me.id = $1
+		ORDER BY score DESC, s.id
+		LIMIT $7`,
+		query.SongID, similarTerms, query.BoostGroup, query.BoostTags, similarGroupBoost, similarTagBoost, query.Limit)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", query.SongID).Msg(repoerrs.ErrFetchSongsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchSongsFailed, err)
+	}
+
+	similar := make([]entity.SimilarSong, 0, len(rows))
+	for _, row := range rows {
+		song := row.SimilarSong
+		song.Tags = row.Tags
+		similar = append(similar, song)
+	}
+
+	logger.Logger.Info().Int64("song_id", query.SongID).Int("count", len(similar)).Msg("Similar songs fetched successfully")
+	return similar, nil
+}
+
+func (r *SongRepo) RefreshSongTerms(ctx context.Context) error {
+	logger.Logger.Debug().Msg("Refreshing song term counts")
+
+	if _, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY library.song_terms`); err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrRefreshTermsFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrRefreshTermsFailed, err)
+	}
+
+	logger.Logger.Info().Msg("Song term counts refreshed successfully")
+	return nil
 }
@@ -0,0 +1,214 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/jmoiron/sqlx"
+)
+
+type StatsRepo struct {
+	db *sqlx.DB
+}
+
+func NewStatsRepo(db *sqlx.DB) *StatsRepo {
+	return &StatsRepo{db: db}
+}
+
+// verseCountExpr counts stanzas the same way lyrics.Verses splits them.
+const verseCountExpr = `array_length(string_to_array(text, E'\n\n'), 1)`
+
+type statsDimension struct {
+	key     string
+	from    string
+	where   string
+	orderBy string
+}
+
+// statsDimensions leave songs without a parseable release date out of years.
+var statsDimensions = map[entity.StatsDimension]statsDimension{
+	entity.StatsByGroup: {
+		key:     `"group"`,
+		from:    `library.songs`,
+		orderBy: `count DESC, key`,
+	},
+	entity.StatsByYear: {
+		key:     `EXTRACT(YEAR FROM ` + releaseDateExpr + `)::int::text`,
+		from:    `library.songs`,
+		where:   ` AND ` + releaseDateExpr + ` IS NOT NULL`,
+		orderBy: `key`,
+	},
+	entity.StatsByTag: {
+		key:     `tag`,
+		from:    `library.songs CROSS JOIN LATERAL unnest(tags) AS tag`,
+		orderBy: `count DESC, key`,
+	},
+}
+
+func (r *StatsRepo) GetSongStats(ctx context.Context, filter entity.SongFilter) (entity.SongStats, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Msg("Computing song stats")
+
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return entity.SongStats{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+	query := `
+		SELECT
+			COUNT(*) AS songs,
+			COALESCE(ROUND(AVG(` + verseCountExpr + `) FILTER (WHERE text <> ''), 2), 0) AS avg_verse_count,
+			COALESCE(ROUND(AVG(char_length(text)) FILTER (WHERE text <> ''), 2), 0) AS avg_lyric_length,
+			COUNT(*) FILTER (WHERE btrim(text) = '') AS missing_text,
+			COUNT(*) FILTER (WHERE btrim(link) = '') AS missing_link
+		FROM library.songs` + where
+
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return entity.SongStats{}, err
+	}
+	defer done()
+
+	var stats entity.SongStats
+	err = sqlx.GetContext(ctx, db, &stats, query, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return entity.SongStats{}, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+
+	logger.Logger.Info().Int("songs", stats.Songs).Msg("Song stats computed successfully")
+	return stats, nil
+}
+
+func (r *StatsRepo) CountSongsBy(ctx context.Context, filter entity.SongFilter, dimension entity.StatsDimension) ([]entity.StatBucket, int, error) {
+	logger.Logger.Debug().
+		Str("dimension", string(dimension)).
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Counting songs by dimension")
+
+	dim, ok := statsDimensions[dimension]
+	if !ok {
+		err := fmt.Errorf("%w: unknown dimension %q", repoerrs.ErrFetchStatsFailed, dimension)
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return nil, 0, err
+	}
+
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+	grouped := `SELECT ` + dim.key + ` AS key, COUNT(*) AS count FROM ` + dim.from + where + dim.where + ` GROUP BY 1`
+
+	db, done, err := songReader(ctx, r.db, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer done()
+
+	var total int
+	err = sqlx.GetContext(ctx, db, &total, `SELECT COUNT(*) FROM (`+grouped+`) AS buckets`, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+
+	query := grouped + ` ORDER BY ` + dim.orderBy
+	argIndex := len(args) + 1
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filter.Limit)
+		argIndex++
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filter.Offset)
+	}
+
+	var buckets []entity.StatBucket
+	err = sqlx.SelectContext(ctx, db, &buckets, query, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+
+	logger.Logger.Info().
+		Str("dimension", string(dimension)).
+		Int("count", len(buckets)).
+		Int("total", total).
+		Msg("Songs counted by dimension successfully")
+	return buckets, total, nil
+}
+
+// CountFacets counts every dimension in one snapshot.
+func (r *StatsRepo) CountFacets(ctx context.Context, filter entity.SongFilter, dimensions []entity.StatsDimension, limit int) ([]entity.Facet, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Int("dimensions", len(dimensions)).
+		Int("limit", limit).
+		Msg("Counting song facets")
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrStartTxFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrStartTxFailed, err)
+	}
+	defer tx.Rollback()
+
+	where, args, err := buildSongFilter(filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+	}
+	if fuzzyFilter(filter) {
+		if err := setSimilarityThreshold(ctx, tx, filter.FuzzyThreshold); err != nil {
+			logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+			return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+		}
+	}
+	facets := make([]entity.Facet, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		dim, ok := statsDimensions[dimension]
+		if !ok {
+			err := fmt.Errorf("%w: unknown dimension %q", repoerrs.ErrFetchStatsFailed, dimension)
+			logger.Logger.Error().Err(err).Msg(repoerrs.ErrFetchStatsFailed.Error())
+			return nil, err
+		}
+
+		var rows []struct {
+			entity.StatBucket
+			Total int `db:"total"`
+		}
+		grouped := `SELECT ` + dim.key + ` AS key, COUNT(*) AS count FROM ` + dim.from + where + dim.where + ` GROUP BY 1`
+		query := `SELECT key, count, COUNT(*) OVER () AS total FROM (` + grouped + `) AS buckets ORDER BY count DESC, key` +
+			fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		err = tx.SelectContext(ctx, &rows, query, append(args, limit)...)
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg(repoerrs.ErrFetchStatsFailed.Error())
+			return nil, fmt.Errorf("%w: %v", repoerrs.ErrFetchStatsFailed, err)
+		}
+
+		facet := entity.Facet{Dimension: dimension, Buckets: make([]entity.StatBucket, 0, len(rows))}
+		for _, row := range rows {
+			facet.Total = row.Total
+			facet.Buckets = append(facet.Buckets, row.StatBucket)
+		}
+		facets = append(facets, facet)
+	}
+
+	logger.Logger.Info().Int("facets", len(facets)).Msg("Song facets counted successfully")
+	return facets, nil
+}
@@ -0,0 +1,302 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type WebhookRepo struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepo(db *sqlx.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+const webhookColumns = `id, url, secret, events, active, created_at`
+
+const deliveryColumns = `id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+func (r *WebhookRepo) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	logger.Logger.Debug().Msg("Fetching webhooks")
+
+	var rows []webhookRow
+	err := r.db.SelectContext(ctx, &rows, `SELECT `+webhookColumns+` FROM library.webhooks ORDER BY id`)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrWebhookFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+	webhooks := make([]entity.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, row.webhook())
+	}
+
+	logger.Logger.Info().Int("count", len(webhooks)).Msg("Webhooks fetched successfully")
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) GetWebhook(ctx context.Context, id int64) (entity.Webhook, error) {
+	logger.Logger.Debug().Int64("id", id).Msg("Fetching webhook")
+
+	var row webhookRow
+	err := r.db.GetContext(ctx, &row, `SELECT `+webhookColumns+` FROM library.webhooks WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("id", id).Msg(repoerrs.ErrWebhookNotFound.Error())
+		return entity.Webhook{}, repoerrs.ErrWebhookNotFound
+	}
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrWebhookFailed.Error())
+		return entity.Webhook{}, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("Webhook fetched successfully")
+	return row.webhook(), nil
+}
+
+func (r *WebhookRepo) AddWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	logger.Logger.Debug().Str("url", webhook.URL).Msg("Adding webhook")
+
+	var created webhookRow
+	err := r.db.GetContext(ctx, &created, `
+		INSERT INTO library.webhooks (url, secret, events, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+webhookColumns, webhook.URL, webhook.Secret, pq.Array(webhook.Events), webhook.Active)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("url", webhook.URL).Msg(repoerrs.ErrWebhookFailed.Error())
+		return entity.Webhook{}, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	logger.Logger.Info().Int64("id", created.ID).Msg("Webhook added successfully")
+	return created.webhook(), nil
+}
+
+func (r *WebhookRepo) UpdateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	logger.Logger.Debug().Int64("id", webhook.ID).Str("url", webhook.URL).Msg("Updating webhook")
+
+	var updated webhookRow
+	err := r.db.GetContext(ctx, &updated, `
+		UPDATE library.webhooks
+		SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), events = $3, active = $4
+		WHERE id = $5
+		RETURNING `+webhookColumns, webhook.URL, webhook.Secret, pq.Array(webhook.Events), webhook.Active, webhook.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("id", webhook.ID).Msg(repoerrs.ErrWebhookNotFound.Error())
+		return entity.Webhook{}, repoerrs.ErrWebhookNotFound
+	}
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", webhook.ID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return entity.Webhook{}, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	logger.Logger.Info().Int64("id", webhook.ID).Msg("Webhook updated successfully")
+	return updated.webhook(), nil
+}
+
+func (r *WebhookRepo) DeleteWebhook(ctx context.Context, id int64) error {
+	logger.Logger.Debug().Int64("id", id).Msg("Deleting webhook")
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM library.webhooks WHERE id = $1`, id)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrWebhookFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrRowsAffectedFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
+	}
+	if rows == 0 {
+		logger.Logger.Warn().Int64("id", id).Msg(repoerrs.ErrWebhookNotFound.Error())
+		return repoerrs.ErrWebhookNotFound
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("Webhook deleted successfully")
+	return nil
+}
+
+func enqueueSongEvents(ctx context.Context, tx *sqlx.Tx, events []entity.SongEvent) error {
+	types := make([]string, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("event", string(event.Type)).Msg(repoerrs.ErrWebhookFailed.Error())
+			return fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+		}
+		types = append(types, string(event.Type))
+		payloads = append(payloads, string(payload))
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO library.webhook_deliveries (webhook_id, event, payload)
+		SELECT w.id, e.type, e.payload::jsonb
+		FROM unnest($1::text[], $2::text[]) AS e(type, payload)
+		JOIN library.webhooks w ON w.active AND e.type = ANY(w.events)`, pq.Array(types), pq.Array(payloads))
+	if err != nil {
+		logger.Logger.Error().Err(err).Int("events", len(events)).Msg(repoerrs.ErrWebhookFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrRowsAffectedFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
+	}
+
+	logger.Logger.Debug().Int("events", len(events)).Int64("count", rows).Msg("Webhook deliveries enqueued")
+	return nil
+}
+
+// ClaimDeliveries counts an attempt of up to limit due deliveries and hides
+// them from other workers for lease, so a worker that dies mid-delivery only
+// delays it.
+func (r *WebhookRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.DeliveryJob, error) {
+	var jobs []entity.DeliveryJob
+	err := r.db.SelectContext(ctx, &jobs, `
+		WITH claimed AS (
+			UPDATE library.webhook_deliveries
+			SET attempts = attempts + 1, next_attempt_at = now() + $2::double precision * interval '1 millisecond'
+			WHERE id IN (
+				SELECT id FROM library.webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= now()
+				ORDER BY next_attempt_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+deliveryColumns+`
+		)
+		SELECT c.*, w.url, w.secret
+		FROM claimed c JOIN library.webhooks w ON w.id = c.webhook_id`, limit, lease.Milliseconds())
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg(repoerrs.ErrWebhookFailed.Error())
+		return nil, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	if len(jobs) > 0 {
+		logger.Logger.Debug().Int("count", len(jobs)).Msg("Webhook deliveries claimed")
+	}
+	return jobs, nil
+}
+
+// MarkDelivered and MarkFailed only touch the delivery while job's claim
+// holds.
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, job entity.DeliveryJob, statusCode int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE library.webhook_deliveries
+		SET status = 'delivered', last_status_code = $1, last_error = '', delivered_at = now()
+		WHERE id = $2 AND status = 'pending' AND attempts = $3 AND next_attempt_at = $4`,
+		statusCode, job.ID, job.Attempts, job.NextAttemptAt)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", job.ID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+	return claimHeld(result, job.ID)
+}
+
+// MarkFailed moves the delivery to the dead letters when nextAttemptAt is nil.
+func (r *WebhookRepo) MarkFailed(ctx context.Context, job entity.DeliveryJob, statusCode *int, lastError string, nextAttemptAt *time.Time) error {
+	status := entity.DeliveryPending
+	if nextAttemptAt == nil {
+		status = entity.DeliveryDead
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE library.webhook_deliveries
+		SET status = $1, last_status_code = $2, last_error = $3, next_attempt_at = COALESCE($4, next_attempt_at)
+		WHERE id = $5 AND status = 'pending' AND attempts = $6 AND next_attempt_at = $7`,
+		string(status), statusCode, lastError, nextAttemptAt, job.ID, job.Attempts, job.NextAttemptAt)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", job.ID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+	return claimHeld(result, job.ID)
+}
+
+func claimHeld(result sql.Result, id int64) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg(repoerrs.ErrRowsAffectedFailed.Error())
+		return fmt.Errorf("%w: %v", repoerrs.ErrRowsAffectedFailed, err)
+	}
+	if rows == 0 {
+		logger.Logger.Warn().Int64("id", id).Msg(repoerrs.ErrDeliveryLeaseLost.Error())
+		return repoerrs.ErrDeliveryLeaseLost
+	}
+	return nil
+}
+
+func (r *WebhookRepo) GetDeliveries(ctx context.Context, filter entity.DeliveryFilter) ([]entity.WebhookDelivery, int, error) {
+	logger.Logger.Debug().
+		Int64("webhook_id", filter.WebhookID).
+		Str("status", string(filter.Status)).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Fetching webhook deliveries")
+
+	where := ` WHERE webhook_id = $1`
+	args := []interface{}{filter.WebhookID}
+	if filter.Status != "" {
+		where += ` AND status = $2`
+		args = append(args, string(filter.Status))
+	}
+
+	var total int
+	err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM library.webhook_deliveries`+where, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("webhook_id", filter.WebhookID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM library.webhook_deliveries` + where + ` ORDER BY id DESC`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+		args = append(args, filter.Offset)
+	}
+
+	var deliveries []entity.WebhookDelivery
+	err = r.db.SelectContext(ctx, &deliveries, query, args...)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("webhook_id", filter.WebhookID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return nil, 0, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	logger.Logger.Info().Int("count", len(deliveries)).Int("total", total).Msg("Webhook deliveries fetched successfully")
+	return deliveries, total, nil
+}
+
+func (r *WebhookRepo) Redeliver(ctx context.Context, webhookID, deliveryID int64) (entity.WebhookDelivery, error) {
+	logger.Logger.Debug().Int64("webhook_id", webhookID).Int64("id", deliveryID).Msg("Redelivering webhook delivery")
+
+	var delivery entity.WebhookDelivery
+	err := r.db.GetContext(ctx, &delivery, `
+		UPDATE library.webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now(), delivered_at = NULL
+		WHERE id = $1 AND webhook_id = $2
+		RETURNING `+deliveryColumns, deliveryID, webhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Logger.Warn().Int64("id", deliveryID).Msg(repoerrs.ErrDeliveryNotFound.Error())
+		return entity.WebhookDelivery{}, repoerrs.ErrDeliveryNotFound
+	}
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", deliveryID).Msg(repoerrs.ErrWebhookFailed.Error())
+		return entity.WebhookDelivery{}, fmt.Errorf("%w: %v", repoerrs.ErrWebhookFailed, err)
+	}
+
+	logger.Logger.Info().Int64("id", deliveryID).Msg("Webhook delivery queued again")
+	return delivery, nil
+}
@@ -2,6 +2,8 @@ package repo
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -11,18 +13,70 @@ import (
 
 type SongRepo interface {
 	GetSongs(ctx context.Context, filter entity.SongFilter) ([]entity.Song, error)
-	GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]string, error)
-	DeleteSong(ctx context.Context, id int64) error
-	UpdateSong(ctx context.Context, song entity.Song) error
-	AddSong(ctx context.Context, song entity.Song) (entity.Song, error)
+	CountSongs(ctx context.Context, filter entity.SongFilter) (int, error)
+	PickSong(ctx context.Context, filter entity.SongFilter, position float64) (entity.Song, error)
+	GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]entity.Verse, int, error)
+	GetSongTexts(ctx context.Context, ids []int64) (map[int64]string, error)
+	SearchLyrics(ctx context.Context, search entity.LyricSearch) ([]entity.LyricVerses, int, error)
+	SuggestSongNames(ctx context.Context, field, value string, threshold float64, limit int) ([]entity.Suggestion, error)
+	CompleteSongNames(ctx context.Context, completion entity.NameCompletion) ([]entity.Completion, error)
+	SimilarSongs(ctx context.Context, query entity.SimilarQuery) ([]entity.SimilarSong, error)
+	RefreshSongTerms(ctx context.Context) error
+	ScanSongs(ctx context.Context, filter entity.SongFilter) iter.Seq2[entity.Song, error]
+	AllSongs(ctx context.Context) iter.Seq2[entity.Song, error]
+	// The writes record the newEvent of every song they change in their own
+	// transaction.
+	DeleteSong(ctx context.Context, id int64, newEvent entity.SongEventFunc) (entity.Song, error)
+	UpdateSong(ctx context.Context, song entity.Song, newEvent entity.SongEventFunc) (entity.Song, error)
+	AddSong(ctx context.Context, song entity.Song, newEvent entity.SongEventFunc) (entity.Song, error)
+	BulkDeleteSongs(ctx context.Context, selector entity.SongSelector, maxRows int, dryRun bool, newEvent entity.SongEventFunc) ([]int64, []entity.Song, error)
+	BulkUpdateSongs(ctx context.Context, selector entity.SongSelector, patch entity.SongPatch, maxRows int, dryRun bool, newEvent entity.SongEventFunc) ([]int64, []entity.Song, error)
+}
+
+type IdempotencyRepo interface {
+	Reserve(ctx context.Context, key, requestHash string, lockedUntil, expiresAt time.Time) (entity.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type StatsRepo interface {
+	GetSongStats(ctx context.Context, filter entity.SongFilter) (entity.SongStats, error)
+	CountSongsBy(ctx context.Context, filter entity.SongFilter, dimension entity.StatsDimension) ([]entity.StatBucket, int, error)
+	CountFacets(ctx context.Context, filter entity.SongFilter, dimensions []entity.StatsDimension, limit int) ([]entity.Facet, error)
+}
+
+type WebhookRepo interface {
+	GetWebhooks(ctx context.Context) ([]entity.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (entity.Webhook, error)
+	AddWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.DeliveryJob, error)
+	MarkDelivered(ctx context.Context, job entity.DeliveryJob, statusCode int) error
+	MarkFailed(ctx context.Context, job entity.DeliveryJob, statusCode *int, lastError string, nextAttemptAt *time.Time) error
+	GetDeliveries(ctx context.Context, filter entity.DeliveryFilter) ([]entity.WebhookDelivery, int, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int64) (entity.WebhookDelivery, error)
+}
+
+type SongEventRepo interface {
+	ListenSongEvents(ctx context.Context, handle func(payload []byte)) error
 }
 
 type Repositories struct {
-	Song SongRepo
+	Song        SongRepo
+	Idempotency IdempotencyRepo
+	Stats       StatsRepo
+	Webhook     WebhookRepo
+	SongEvent   SongEventRepo
 }
 
-func NewRepositories(db *sqlx.DB) *Repositories {
+func NewRepositories(db *sqlx.DB, dsn string) *Repositories {
 	return &Repositories{
-		Song: pgdb.NewSongRepo(db),
+		Song:        pgdb.NewSongRepo(db),
+		Idempotency: pgdb.NewIdempotencyRepo(db),
+		Stats:       pgdb.NewStatsRepo(db),
+		Webhook:     pgdb.NewWebhookRepo(db),
+		SongEvent:   pgdb.NewSongEventRepo(db, dsn),
 	}
 }
@@ -8,9 +8,21 @@ var (
 	ErrUpdateFailed       = errors.New("failed to update song")
 	ErrDeleteFailed       = errors.New("failed to delete song")
 	ErrFetchSongsFailed   = errors.New("failed to fetch songs")
+	ErrCountSongsFailed   = errors.New("failed to count songs")
 	ErrFetchVersesFailed  = errors.New("failed to fetch song verses")
+	ErrFetchStatsFailed   = errors.New("failed to fetch song stats")
 	ErrStartTxFailed      = errors.New("failed to start transaction")
 	ErrCommitTxFailed     = errors.New("failed to commit transaction")
 	ErrRollbackTxFailed   = errors.New("failed to rollback transaction")
 	ErrRowsAffectedFailed = errors.New("failed to check affected rows")
+	ErrIdempotencyFailed  = errors.New("failed to access idempotency key")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrWebhookFailed      = errors.New("failed to access webhooks")
+	ErrDeliveryLeaseLost  = errors.New("webhook delivery lease lost")
+	ErrNotifyFailed       = errors.New("failed to notify song event")
+	ErrListenFailed       = errors.New("failed to listen for song events")
+	ErrBulkFailed         = errors.New("failed to select songs for bulk operation")
+	ErrTooManyRows        = errors.New("bulk operation matches too many songs")
+	ErrRefreshTermsFailed = errors.New("failed to refresh song term counts")
 )
@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"errors"
+	"strconv"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+	"github.com/Zorynix/song-library/internal/validation"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type codedError struct {
+	err    error
+	code   string
+	fields []errs.FieldError
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if len(e.fields) > 0 {
+		extensions["errors"] = e.fields
+	}
+	return extensions
+}
+
+func resolverError(err error) error {
+	target, code := errs.Classify(err)
+	if target == errs.ErrInternal {
+		return &codedError{err: target, code: code}
+	}
+	coded := &codedError{err: err, code: code}
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		coded.err = errs.ErrInvalidInput
+		coded.fields = validationErr.Fields
+	}
+	return coded
+}
+
+func parseID(id gql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		var violations validation.Violations
+		violations.Add("id", "must be a positive integer")
+		return 0, resolverError(violations.Err())
+	}
+	return n, nil
+}
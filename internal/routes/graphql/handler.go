@@ -0,0 +1,19 @@
+package graphql
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/Zorynix/song-library/internal/services"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var schema string
+
+func NewHandler(services *services.Services) http.Handler {
+	return &relay.Handler{
+		Schema: gql.MustParseSchema(schema, &Resolver{services: services}),
+	}
+}
@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/services"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type Resolver struct {
+	services *services.Services
+}
+
+type songFilterInput struct {
+	Group      *string
+	Title      *string
+	Text       *string
+	Query      *string
+	Fuzzy      *bool
+	Lang       *string
+	Expression *string
+	Limit      *int32
+	Offset     *int32
+}
+
+type newSongInput struct {
+	Group string
+	Title string
+	Tags  *[]string
+}
+
+type songInput struct {
+	Group       string
+	Title       string
+	ReleaseDate string
+	Text        string
+	Link        string
+	Tags        *[]string
+	Language    *string
+}
+
+func (r *Resolver) Songs(ctx context.Context, args struct{ Filter *songFilterInput }) (*songConnectionResolver, error) {
+	var filter entity.SongFilter
+	if f := args.Filter; f != nil {
+		filter.Group = deref(f.Group)
+		filter.Title = deref(f.Title)
+		filter.Text = deref(f.Text)
+		filter.Query = deref(f.Query)
+		filter.Fuzzy = deref(f.Fuzzy)
+		filter.Language = deref(f.Lang)
+		filter.Filter = deref(f.Expression)
+		filter.Limit = int(deref(f.Limit))
+		filter.Offset = int(deref(f.Offset))
+	}
+
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Resolving songs query")
+
+	songs, total, err := r.services.Song.GetSongs(ctx, filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to resolve songs query")
+		return nil, resolverError(err)
+	}
+
+	return &songConnectionResolver{
+		items: newSongResolvers(r.services, songs),
+		total: total,
+	}, nil
+}
+
+func (r *Resolver) AddSong(ctx context.Context, args struct{ Input newSongInput }) (*songResolver, error) {
+	logger.Logger.Debug().Str("group", args.Input.Group).Str("title", args.Input.Title).Msg("Resolving addSong mutation")
+
+	song, err := r.services.Song.AddSong(ctx, entity.Song{Group: args.Input.Group, Title: args.Input.Title, Tags: deref(args.Input.Tags)})
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to resolve addSong mutation")
+		return nil, resolverError(err)
+	}
+
+	return newSongResolvers(r.services, []entity.Song{song})[0], nil
+}
+
+func (r *Resolver) UpdateSong(ctx context.Context, args struct {
+	ID    gql.ID
+	Input songInput
+}) (*songResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Debug().Int64("id", id).Msg("Resolving updateSong mutation")
+
+	song := entity.Song{
+		ID:          id,
+		Group:       args.Input.Group,
+		Title:       args.Input.Title,
+		ReleaseDate: args.Input.ReleaseDate,
+		Text:        args.Input.Text,
+		Link:        args.Input.Link,
+		Tags:        deref(args.Input.Tags),
+		Language:    deref(args.Input.Language),
+	}
+	updated, err := r.services.Song.UpdateSong(ctx, song)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to resolve updateSong mutation")
+		return nil, resolverError(err)
+	}
+
+	return newSongResolvers(r.services, []entity.Song{updated})[0], nil
+}
+
+func (r *Resolver) DeleteSong(ctx context.Context, args struct{ ID gql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	logger.Logger.Debug().Int64("id", id).Msg("Resolving deleteSong mutation")
+
+	if err := r.services.Song.DeleteSong(ctx, id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to resolve deleteSong mutation")
+		return false, resolverError(err)
+	}
+
+	return true, nil
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
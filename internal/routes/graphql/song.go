@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/Zorynix/song-library/internal/lyrics"
+	"github.com/Zorynix/song-library/internal/services"
+	"github.com/Zorynix/song-library/internal/validation"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type songConnectionResolver struct {
+	items []*songResolver
+	total int
+}
+
+func (r *songConnectionResolver) Items() []*songResolver {
+	return r.items
+}
+
+func (r *songConnectionResolver) Total() int32 {
+	return int32(r.total)
+}
+
+type songResolver struct {
+	song   entity.Song
+	verses *verseBatch
+}
+
+// newSongResolvers shares one verseBatch so verses of a list load in one query.
+func newSongResolvers(services *services.Services, songs []entity.Song) []*songResolver {
+	batch := &verseBatch{services: services, ids: make([]int64, 0, len(songs))}
+	resolvers := make([]*songResolver, 0, len(songs))
+	for _, song := range songs {
+		batch.ids = append(batch.ids, song.ID)
+		resolvers = append(resolvers, &songResolver{song: song, verses: batch})
+	}
+	return resolvers
+}
+
+func (r *songResolver) ID() gql.ID {
+	return gql.ID(strconv.FormatInt(r.song.ID, 10))
+}
+
+func (r *songResolver) Group() string {
+	return r.song.Group
+}
+
+func (r *songResolver) Title() string {
+	return r.song.Title
+}
+
+func (r *songResolver) ReleaseDate() string {
+	return r.song.ReleaseDate
+}
+
+func (r *songResolver) Text() string {
+	return r.song.Text
+}
+
+func (r *songResolver) Link() string {
+	return r.song.Link
+}
+
+func (r *songResolver) Tags() []string {
+	if r.song.Tags == nil {
+		return []string{}
+	}
+	return r.song.Tags
+}
+
+func (r *songResolver) Language() string {
+	return r.song.Language
+}
+
+func (r *songResolver) Verses(ctx context.Context, args struct {
+	Limit  *int32
+	Offset *int32
+}) (*verseConnectionResolver, error) {
+	pagination := entity.VersePagination{
+		SongID: r.song.ID,
+		Limit:  int(deref(args.Limit)),
+		Offset: int(deref(args.Offset)),
+	}
+	if err := validation.VersePagination(pagination); err != nil {
+		return nil, resolverError(err)
+	}
+
+	verses, err := r.verses.load(ctx, r.song.ID)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	return &verseConnectionResolver{
+		items: lyrics.Page(verses, pagination.Limit, pagination.Offset),
+		total: len(verses),
+	}, nil
+}
+
+type verseConnectionResolver struct {
+	items []string
+	total int
+}
+
+func (r *verseConnectionResolver) Items() []string {
+	return r.items
+}
+
+func (r *verseConnectionResolver) Total() int32 {
+	return int32(r.total)
+}
+
+type verseBatch struct {
+	services *services.Services
+	ids      []int64
+
+	once   sync.Once
+	verses map[int64][]string
+	err    error
+}
+
+func (b *verseBatch) load(ctx context.Context, id int64) ([]string, error) {
+	b.once.Do(func() {
+		b.verses, b.err = b.services.Song.GetVersesBySongIDs(ctx, b.ids)
+	})
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.verses[id], nil
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"errors"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var statusCodes = map[string]codes.Code{
+	errs.CodeSongInfoNotFound:  codes.NotFound,
+	errs.CodeNotFound:          codes.NotFound,
+	errs.CodeInvalidInput:      codes.InvalidArgument,
+	errs.CodeBadRequest:        codes.InvalidArgument,
+	errs.CodeNotAcceptable:     codes.InvalidArgument,
+	errs.CodeKeyInProgress:     codes.Aborted,
+	errs.CodeKeyReused:         codes.FailedPrecondition,
+	errs.CodeMusicAPIFailed:    codes.Unavailable,
+	errs.CodeOperationFailed:   codes.Internal,
+	errs.CodeUnavailable:       codes.Unavailable,
+	errs.CodeBulkLimitExceeded: codes.InvalidArgument,
+	errs.CodeInternal:          codes.Internal,
+}
+
+// statusError hides the detail of internal errors.
+func statusError(err error) error {
+	target, name := errs.Classify(err)
+	code, ok := statusCodes[name]
+	if !ok {
+		code = codes.Internal
+	}
+	msg := err.Error()
+	if code == codes.Internal {
+		msg = target.Error()
+	}
+
+	var validationErr *errs.ValidationError
+	if !errors.As(err, &validationErr) {
+		return status.Error(code, msg)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, f := range validationErr.Fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+		})
+	}
+
+	st, detailsErr := status.New(code, errs.ErrInvalidInput.Error()).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
@@ -0,0 +1,195 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/services"
+	songv1 "github.com/Zorynix/song-library/pkg/api/song/v1"
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	songv1.UnimplementedSongServiceServer
+	services *services.Services
+}
+
+func NewServer(services *services.Services) *Server {
+	return &Server{services: services}
+}
+
+func (s *Server) Register(server *grpc.Server) {
+	songv1.RegisterSongServiceServer(server, s)
+}
+
+func (s *Server) ListSongs(ctx context.Context, req *songv1.ListSongsRequest) (*songv1.ListSongsResponse, error) {
+	filter := songFilter(req)
+
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Handling ListSongs call")
+
+	songs, total, err := s.services.Song.GetSongs(ctx, filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle ListSongs call")
+		return nil, statusError(err)
+	}
+
+	logger.Logger.Info().Int("count", len(songs)).Int("total", total).Msg("ListSongs call handled successfully")
+	return &songv1.ListSongsResponse{Songs: toProtoSongs(songs), Total: int32(total)}, nil
+}
+
+func (s *Server) StreamSongs(req *songv1.ListSongsRequest, stream grpc.ServerStreamingServer[songv1.Song]) error {
+	filter := songFilter(req)
+
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Handling StreamSongs call")
+
+	songs, err := s.services.Song.StreamSongs(stream.Context(), filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid StreamSongs request")
+		return statusError(err)
+	}
+
+	sent := 0
+	for song, err := range songs {
+		if err != nil {
+			logger.Logger.Error().Err(err).Int("sent", sent).Msg("Failed to handle StreamSongs call")
+			return statusError(err)
+		}
+		if err := stream.Send(toProtoSong(song)); err != nil {
+			logger.Logger.Error().Err(err).Int("sent", sent).Msg("Failed to send song to stream")
+			return err
+		}
+		sent++
+	}
+
+	logger.Logger.Info().Int("sent", sent).Msg("StreamSongs call handled successfully")
+	return nil
+}
+
+func (s *Server) GetSongVerses(ctx context.Context, req *songv1.GetSongVersesRequest) (*songv1.GetSongVersesResponse, error) {
+	pagination := entity.VersePagination{
+		SongID: req.GetSongId(),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+		Unit:   entity.VerseUnit(req.GetUnit()),
+	}
+
+	logger.Logger.Debug().
+		Int64("song_id", pagination.SongID).
+		Int("limit", pagination.Limit).
+		Int("offset", pagination.Offset).
+		Msg("Handling GetSongVerses call")
+
+	verses, total, err := s.services.Song.GetSongVerses(ctx, pagination)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", pagination.SongID).Msg("Failed to handle GetSongVerses call")
+		return nil, statusError(err)
+	}
+
+	logger.Logger.Info().Int64("song_id", pagination.SongID).Int("verse_count", len(verses)).Msg("GetSongVerses call handled successfully")
+	texts := make([]string, 0, len(verses))
+	for _, verse := range verses {
+		texts = append(texts, verse.Text)
+	}
+	return &songv1.GetSongVersesResponse{Verses: texts, Total: int32(total)}, nil
+}
+
+func (s *Server) AddSong(ctx context.Context, req *songv1.AddSongRequest) (*songv1.Song, error) {
+	logger.Logger.Debug().Str("group", req.GetGroup()).Str("title", req.GetTitle()).Msg("Handling AddSong call")
+
+	song, err := s.services.Song.AddSong(ctx, entity.Song{Group: req.GetGroup(), Title: req.GetTitle(), Tags: req.GetTags()})
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("group", req.GetGroup()).Str("title", req.GetTitle()).Msg("Failed to handle AddSong call")
+		return nil, statusError(err)
+	}
+
+	logger.Logger.Info().Int64("id", song.ID).Msg("AddSong call handled successfully")
+	return toProtoSong(song), nil
+}
+
+func (s *Server) UpdateSong(ctx context.Context, req *songv1.UpdateSongRequest) (*songv1.Song, error) {
+	song := fromProtoSong(req.GetSong())
+
+	logger.Logger.Debug().Int64("id", song.ID).Str("group", song.Group).Str("title", song.Title).Msg("Handling UpdateSong call")
+
+	updated, err := s.services.Song.UpdateSong(ctx, song)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", song.ID).Msg("Failed to handle UpdateSong call")
+		return nil, statusError(err)
+	}
+
+	logger.Logger.Info().Int64("id", song.ID).Msg("UpdateSong call handled successfully")
+	return toProtoSong(updated), nil
+}
+
+func (s *Server) DeleteSong(ctx context.Context, req *songv1.DeleteSongRequest) (*songv1.DeleteSongResponse, error) {
+	logger.Logger.Debug().Int64("id", req.GetId()).Msg("Handling DeleteSong call")
+
+	if err := s.services.Song.DeleteSong(ctx, req.GetId()); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", req.GetId()).Msg("Failed to handle DeleteSong call")
+		return nil, statusError(err)
+	}
+
+	logger.Logger.Info().Int64("id", req.GetId()).Msg("DeleteSong call handled successfully")
+	return &songv1.DeleteSongResponse{}, nil
+}
+
+func songFilter(req *songv1.ListSongsRequest) entity.SongFilter {
+	return entity.SongFilter{
+		Group:    req.GetFilter().GetGroup(),
+		Title:    req.GetFilter().GetTitle(),
+		Text:     req.GetFilter().GetText(),
+		Query:    req.GetFilter().GetQuery(),
+		Fuzzy:    req.GetFilter().GetFuzzy(),
+		Language: req.GetFilter().GetLang(),
+		Limit:    int(req.GetLimit()),
+		Offset:   int(req.GetOffset()),
+		Filter:   req.GetFilter().GetExpression(),
+	}
+}
+
+func toProtoSongs(songs []entity.Song) []*songv1.Song {
+	result := make([]*songv1.Song, 0, len(songs))
+	for _, song := range songs {
+		result = append(result, toProtoSong(song))
+	}
+	return result
+}
+
+func toProtoSong(song entity.Song) *songv1.Song {
+	return &songv1.Song{
+		Id:          song.ID,
+		Group:       song.Group,
+		Title:       song.Title,
+		ReleaseDate: song.ReleaseDate,
+		Text:        song.Text,
+		Link:        song.Link,
+		Tags:        song.Tags,
+		Language:    song.Language,
+	}
+}
+
+func fromProtoSong(song *songv1.Song) entity.Song {
+	return entity.Song{
+		ID:          song.GetId(),
+		Group:       song.GetGroup(),
+		Title:       song.GetTitle(),
+		ReleaseDate: song.GetReleaseDate(),
+		Text:        song.GetText(),
+		Link:        song.GetLink(),
+		Tags:        song.GetTags(),
+		Language:    song.GetLanguage(),
+	}
+}
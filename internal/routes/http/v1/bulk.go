@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+)
+
+// BulkDeleteSongs удаляет несколько песен
+// @Summary Массовое удаление песен
+// @Description Удаляет песни из списка ids или подходящие под filter (те же условия, что у GET /songs) в одной транзакции.
+// @Description Если выбрано больше песен, чем разрешено настройкой bulk.max_rows, ничего не удаляется.
+// @Description С dryRun=true возвращает, что было бы удалено, не меняя данные.
+// @Tags Songs
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
+// @Param request body entity.BulkDelete true "Выбор песен"
+// @Success 200 {object} entity.BulkResult "Результат по каждой песне"
+// @Failure 400 {object} Problem "Неверный запрос"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Выбрано слишком много песен или ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs:bulk-delete [post]
+func (h *Handler) BulkDeleteSongs(w http.ResponseWriter, r *http.Request) {
+	var bulk entity.BulkDelete
+	if err := json.NewDecoder(r.Body).Decode(&bulk); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode bulk delete request")
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
+		return
+	}
+
+	logger.Logger.Debug().Int("ids", len(bulk.IDs)).Bool("dry_run", bulk.DryRun).Msg("Handling BulkDeleteSongs request")
+
+	result, err := h.services.Song.BulkDeleteSongs(r.Context(), bulk)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle BulkDeleteSongs request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("matched", result.Matched).Int("deleted", result.Changed).Msg("BulkDeleteSongs request handled successfully")
+	respond(w, r, http.StatusOK, result)
+}
+
+// BulkUpdateSongs обновляет несколько песен
+// @Summary Массовое обновление песен
+// @Description Задаёт поля из set (group, title, releaseDate, link, tags) песням из списка ids или подходящим под filter
+// @Description в одной транзакции. Если выбрано больше песен, чем разрешено настройкой bulk.max_rows, ничего не меняется.
+// @Description С dryRun=true возвращает песни в том виде, какими они стали бы, не меняя данные.
+// @Tags Songs
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
+// @Param request body entity.BulkUpdate true "Выбор песен и новые значения полей"
+// @Success 200 {object} entity.BulkResult "Результат по каждой песне"
+// @Failure 400 {object} Problem "Неверный запрос"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Выбрано слишком много песен или ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs:bulk-update [post]
+func (h *Handler) BulkUpdateSongs(w http.ResponseWriter, r *http.Request) {
+	var bulk entity.BulkUpdate
+	if err := json.NewDecoder(r.Body).Decode(&bulk); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode bulk update request")
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
+		return
+	}
+
+	logger.Logger.Debug().Int("ids", len(bulk.IDs)).Bool("dry_run", bulk.DryRun).Msg("Handling BulkUpdateSongs request")
+
+	result, err := h.services.Song.BulkUpdateSongs(r.Context(), bulk)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle BulkUpdateSongs request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("matched", result.Matched).Int("updated", result.Changed).Msg("BulkUpdateSongs request handled successfully")
+	respond(w, r, http.StatusOK, result)
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheable(t *testing.T) {
+	handler := negotiate(listFormats)(cacheable(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, http.StatusOK, []renderedSong{{ID: 1, Group: "Muse"}})
+	})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats/groups", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Body.Len() == 0 {
+		t.Fatalf("first response = %d with ETag %q and %d bytes, want 200 with an ETag and a body", rec.Code, etag, rec.Body.Len())
+	}
+	if vary := rec.Header().Values("Vary"); len(vary) != 1 || vary[0] != "Accept" {
+		t.Errorf("Vary = %q, want Accept", vary)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/groups", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("revalidation = %d with %d bytes, want 304 without a body", rec.Code, rec.Body.Len())
+	}
+	if vary := rec.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("Vary of 304 = %q, want Accept", vary)
+	}
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+)
+
+// StreamEvents отдаёт поток изменений песен
+// @Summary Поток событий песен
+// @Description Server-Sent Events с событиями song.created, song.updated и song.deleted. Поле id каждого события
+// @Description можно передать в заголовке Last-Event-ID (или параметре lastEventId), чтобы получить пропущенные события
+// @Description из ограниченной истории. Если событие уже вытеснено из истории, первым приходит событие reset и клиенту
+// @Description нужно перезагрузить данные. Текст песни в событиях не передаётся.
+// @Tags Events
+// @Produce text/event-stream
+// @Produce application/problem+json
+// @Param Last-Event-ID header string false "ID последнего полученного события"
+// @Param lastEventId query string false "ID последнего полученного события, если заголовок задать нельзя"
+// @Param group query []string false "Только события песен этих групп" collectionFormat(multi)
+// @Success 200 {object} entity.SongEvent "Поток событий"
+// @Failure 503 {object} Problem "Сервис останавливается"
+// @Router /events [get]
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("lastEventId")
+	}
+	var groups []string
+	for _, group := range query["group"] {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	logger.Logger.Debug().Str("last_event_id", lastEventID).Strs("groups", groups).Msg("Handling StreamEvents request")
+
+	sub, err := h.services.Events.Subscribe(lastEventID, groups)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle StreamEvents request")
+		writeError(w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	flush := func() bool {
+		if err := rc.Flush(); err != nil {
+			logger.Logger.Debug().Err(err).Msg("Event stream closed")
+			return false
+		}
+		return true
+	}
+
+	if sub.Reset {
+		if err := writeSSE(w, "", "reset", []byte("{}")); err != nil {
+			return
+		}
+	}
+	for _, event := range sub.Missed {
+		if err := writeSongEvent(w, event); err != nil {
+			return
+		}
+	}
+	if !flush() {
+		return
+	}
+
+	logger.Logger.Info().Int("missed", len(sub.Missed)).Bool("reset", sub.Reset).Msg("Event stream started")
+
+	heartbeat := time.NewTicker(h.options.EventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			logger.Logger.Debug().Msg("Event stream client disconnected")
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				logger.Logger.Debug().Msg("Event stream closed by server")
+				return
+			}
+			if err := writeSongEvent(w, event); err != nil || !flush() {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil || !flush() {
+				return
+			}
+		}
+	}
+}
+
+func writeSongEvent(w io.Writer, event entity.SongEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("event", event.ID).Msg("Failed to encode song event")
+		return err
+	}
+	return writeSSE(w, event.ID, string(event.Type), data)
+}
+
+// writeSSE writes one event; data must not contain newlines.
+func writeSSE(w io.Writer, id, name string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", name, data)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
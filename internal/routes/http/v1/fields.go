@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const fieldsParam = "fields"
+
+func queryFields(r *http.Request, defaults []string) []string {
+	query := r.URL.Query()
+	if !query.Has(fieldsParam) {
+		return defaults
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(query.Get(fieldsParam), ",") {
+		if field = strings.TrimSpace(field); field != "" && !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+var projectionTypes sync.Map
+
+type projectionKey struct {
+	elem   reflect.Type
+	fields string
+}
+
+func selectFields(items any, fields []string) any {
+	src := reflect.ValueOf(items)
+	projected := projectionType(src.Type().Elem(), fields)
+
+	dst := reflect.MakeSlice(reflect.SliceOf(projected), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		item, out := src.Index(i), dst.Index(i)
+		for j := 0; j < projected.NumField(); j++ {
+			out.Field(j).Set(item.FieldByName(projected.Field(j).Name))
+		}
+	}
+	return dst.Interface()
+}
+
+func projectionType(elem reflect.Type, fields []string) reflect.Type {
+	key := projectionKey{elem: elem, fields: strings.Join(fields, ",")}
+	if t, ok := projectionTypes.Load(key); ok {
+		return t.(reflect.Type)
+	}
+
+	var structFields []reflect.StructField
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if slices.Contains(fields, name) {
+			structFields = append(structFields, field)
+		}
+	}
+
+	t, _ := projectionTypes.LoadOrStore(key, reflect.StructOf(structFields))
+	return t.(reflect.Type)
+}
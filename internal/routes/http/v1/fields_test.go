@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+func TestQueryFields(t *testing.T) {
+	defaults := []string{"id", "group"}
+
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{url: "/songs", want: defaults},
+		{url: "/songs?fields=title,%20id,title,", want: []string{"title", "id"}},
+		{url: "/songs?fields=", want: []string{}},
+		{url: "/songs?fields=,%20,", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		got := queryFields(httptest.NewRequest(http.MethodGet, tt.url, nil), defaults)
+		if !slices.Equal(got, tt.want) || got == nil {
+			t.Errorf("queryFields(%q) = %#v, want %#v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	songs := []entity.Song{
+		{ID: 1, Group: "Muse", Title: "Uprising", Text: "They will not force us"},
+		{ID: 2, Group: "Muse", Title: "Starlight"},
+	}
+
+	projected := selectFields(songs, []string{"title", "id", "unknown"})
+	payload, err := json.Marshal(projected)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	// Fields keep their order in the entity, whatever the order asked for.
+	want := `[{"id":1,"title":"Uprising"},{"id":2,"title":"Starlight"}]`
+	if string(payload) != want {
+		t.Errorf("selectFields() = %s, want %s", payload, want)
+	}
+
+	again := selectFields(songs[:1], []string{"title", "id", "unknown"})
+	if reflect.TypeOf(again) != reflect.TypeOf(projected) {
+		t.Errorf("selectFields() built a new type for the same fieldset")
+	}
+}
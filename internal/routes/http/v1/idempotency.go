@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/validation"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// idempotent replays the stored response for a repeated Idempotency-Key.
+func (h *Handler) idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			var violations validation.Violations
+			violations.Add(idempotencyKeyHeader, fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))
+			writeError(w, r, violations.Err())
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to read idempotent request body")
+			writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := h.services.Idempotency.Begin(r.Context(), key, requestHash(r, body))
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to begin idempotent request")
+			writeError(w, r, err)
+			return
+		}
+
+		if record != nil {
+			logger.Logger.Info().Str("key", key).Msg("Replaying idempotent response")
+			if record.ContentType != "" {
+				w.Header().Set("Content-Type", record.ContentType)
+			}
+			w.Header().Set(idempotentReplayedHeader, "true")
+			w.WriteHeader(*record.StatusCode)
+			w.Write(record.ResponseBody)
+			return
+		}
+
+		var recorded bytes.Buffer
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&recorded)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		// Recorded even if the client is gone, or the key stays pending.
+		ctx := context.WithoutCancel(r.Context())
+
+		if status >= http.StatusInternalServerError {
+			if err := h.services.Idempotency.Release(ctx, key); err != nil {
+				logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to release idempotency key")
+			}
+			return
+		}
+
+		if err := h.services.Idempotency.Complete(ctx, key, status, ww.Header().Get("Content-Type"), recorded.Bytes()); err != nil {
+			logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to store idempotent response")
+		}
+	})
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write([]byte(responseFormat(r).mediaType))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
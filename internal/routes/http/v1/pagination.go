@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+const envelopeParam = "envelope"
+
+func writePage(w http.ResponseWriter, r *http.Request, items any, total, limit, offset int) {
+	writeEnvelopedPage(w, r, entity.Page{Items: items, Total: total, Limit: limit, Offset: offset})
+}
+
+func writeEnvelopedPage(w http.ResponseWriter, r *http.Request, page entity.Page) {
+	if v := reflect.ValueOf(page.Items); v.Kind() == reflect.Slice && v.IsNil() {
+		page.Items = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+
+	page.Next, page.Prev = pageLinks(r, page.Total, page.Limit, page.Offset)
+
+	var links []string
+	if page.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, page.Next))
+	}
+	if page.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, page.Prev))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+
+	envelope, _ := strconv.ParseBool(r.URL.Query().Get(envelopeParam))
+	if !envelope || responseFormat(r).mediaType == csvFormat.mediaType {
+		respond(w, r, http.StatusOK, page.Items)
+		return
+	}
+
+	respond(w, r, http.StatusOK, page)
+}
+
+func pageLinks(r *http.Request, total, limit, offset int) (next, prev string) {
+	if limit <= 0 {
+		return "", ""
+	}
+
+	if offset+limit < total {
+		next = pageURL(r, limit, offset+limit)
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prev = pageURL(r, limit, prevOffset)
+	}
+
+	return next, prev
+}
+
+func pageURL(r *http.Request, limit, offset int) string {
+	u := *r.URL
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+type paramParser struct {
+	r          *http.Request
+	violations validation.Violations
+}
+
+func newParamParser(r *http.Request) *paramParser {
+	return &paramParser{r: r}
+}
+
+func (p *paramParser) pathID(name string) int64 {
+	raw := chi.URLParam(p.r, name)
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		p.violations.Add(name, "must be a positive integer")
+	}
+	return id
+}
+
+func (p *paramParser) pathInt(name string) int {
+	raw := chi.URLParam(p.r, name)
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		p.violations.Add(name, "must be an integer")
+	}
+	return n
+}
+
+func (p *paramParser) queryInt(name string) int {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		p.violations.Add(name, "must be an integer")
+	}
+	return n
+}
+
+func (p *paramParser) queryBool(name string) bool {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		p.violations.Add(name, "must be a boolean")
+	}
+	return b
+}
+
+func (p *paramParser) queryLocation(name string) *time.Location {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(raw)
+	if err != nil {
+		p.violations.Add(name, "must be an IANA time zone such as Europe/Moscow")
+		return time.Local
+	}
+	return loc
+}
+
+func (p *paramParser) queryDate(name string, loc *time.Location) (date time.Time, ok bool) {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(time.DateOnly, raw, loc)
+	if err != nil {
+		p.violations.Add(name, "must be a date as YYYY-MM-DD")
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func (p *paramParser) err(validate func() error) error {
+	if p.violations.Err() == nil {
+		return nil
+	}
+	if validate != nil {
+		p.violations.Merge(validate())
+	}
+	return p.violations.Err()
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:song-library:problem:"
+)
+
+// Problem is an RFC 7807 error body.
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code"`
+	RequestID string            `json:"request_id,omitempty"`
+	Errors    []errs.FieldError `json:"errors,omitempty" swaggertype:"array,object"`
+}
+
+var problemStatuses = map[string]int{
+	errs.CodeSongInfoNotFound:  http.StatusNotFound,
+	errs.CodeNotFound:          http.StatusNotFound,
+	errs.CodeInvalidInput:      http.StatusBadRequest,
+	errs.CodeBadRequest:        http.StatusBadRequest,
+	errs.CodeNotAcceptable:     http.StatusNotAcceptable,
+	errs.CodeKeyInProgress:     http.StatusConflict,
+	errs.CodeKeyReused:         http.StatusUnprocessableEntity,
+	errs.CodeMusicAPIFailed:    http.StatusBadGateway,
+	errs.CodeOperationFailed:   http.StatusInternalServerError,
+	errs.CodeUnavailable:       http.StatusServiceUnavailable,
+	errs.CodeBulkLimitExceeded: http.StatusUnprocessableEntity,
+	errs.CodeInternal:          http.StatusInternalServerError,
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	target, code := errs.Classify(err)
+	status, ok := problemStatuses[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := Problem{
+		Type:      problemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    target.Error(),
+		Instance:  r.URL.RequestURI(),
+		Code:      code,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+
+	if status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+	}
+
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		problem.Detail = errs.ErrInvalidInput.Error()
+		problem.Errors = validationErr.Fields
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
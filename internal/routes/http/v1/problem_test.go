@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: errs.ErrSongInfoNotFound, status: http.StatusNotFound, code: "song_info_not_found"},
+		{err: fmt.Errorf("%w: song 7", errs.ErrNotFound), status: http.StatusNotFound, code: "not_found"},
+		{err: &errs.ValidationError{Fields: []errs.FieldError{{Field: "q", Message: "is required"}}}, status: http.StatusBadRequest, code: "invalid_input"},
+		{err: errs.ErrNotAcceptable, status: http.StatusNotAcceptable, code: "not_acceptable"},
+		{err: errs.ErrKeyInProgress, status: http.StatusConflict, code: "idempotency_key_in_progress"},
+		{err: errs.ErrKeyReused, status: http.StatusUnprocessableEntity, code: "idempotency_key_reused"},
+		{err: errs.ErrMusicAPIFailed, status: http.StatusBadGateway, code: "music_api_failed"},
+		{err: errs.ErrUnavailable, status: http.StatusServiceUnavailable, code: "unavailable"},
+		{err: errs.ErrBulkLimitExceeded, status: http.StatusUnprocessableEntity, code: "bulk_limit_exceeded"},
+		{err: errors.New("connection refused"), status: http.StatusInternalServerError, code: "internal_error"},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeError(rec, httptest.NewRequest(http.MethodGet, "/songs", nil), tt.err)
+
+		var problem Problem
+		if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+			t.Fatalf("writeError(%v) body: %v", tt.err, err)
+		}
+		if rec.Code != tt.status || problem.Status != tt.status || problem.Code != tt.code {
+			t.Errorf("writeError(%v) = %d %q, want %d %q", tt.err, rec.Code, problem.Code, tt.status, tt.code)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type format struct {
+	mediaType string
+	aliases   []string
+	encode    func(w io.Writer, v any) error
+}
+
+var (
+	jsonFormat = format{
+		mediaType: "application/json",
+		encode: func(w io.Writer, v any) error {
+			return json.NewEncoder(w).Encode(v)
+		},
+	}
+	xmlFormat = format{
+		mediaType: "application/xml",
+		aliases:   []string{"text/xml"},
+		encode:    encodeXML,
+	}
+	csvFormat = format{
+		mediaType: "text/csv",
+		encode:    encodeCSV,
+	}
+	msgpackFormat = format{
+		mediaType: "application/msgpack",
+		aliases:   []string{"application/x-msgpack", "application/vnd.msgpack"},
+		encode: func(w io.Writer, v any) error {
+			enc := msgpack.NewEncoder(w)
+			enc.SetCustomStructTag("json")
+			return enc.Encode(v)
+		},
+	}
+
+	objectFormats = []format{jsonFormat, xmlFormat, msgpackFormat}
+	listFormats   = []format{jsonFormat, xmlFormat, csvFormat, msgpackFormat}
+)
+
+type formatCtxKey struct{}
+
+func negotiate(formats []format) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f, ok := selectFormat(r.Header.Get("Accept"), formats)
+			if !ok {
+				offered := make([]string, 0, len(formats))
+				for _, f := range formats {
+					offered = append(offered, f.mediaType)
+				}
+				writeError(w, r, fmt.Errorf("%w: supported media types are %s", errs.ErrNotAcceptable, strings.Join(offered, ", ")))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), formatCtxKey{}, f)))
+		})
+	}
+}
+
+func respond(w http.ResponseWriter, r *http.Request, status int, v any) {
+	f := responseFormat(r)
+	w.Header().Set("Content-Type", f.mediaType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	f.encode(w, v)
+}
+
+func responseFormat(r *http.Request) format {
+	if f, ok := r.Context().Value(formatCtxKey{}).(format); ok {
+		return f
+	}
+	return jsonFormat
+}
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+func selectFormat(accept string, formats []format) (format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return formats[0], true
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, mr := range ranges {
+		for _, f := range formats {
+			if f.matches(mr.mediaType) {
+				return f, true
+			}
+		}
+	}
+	return format{}, false
+}
+
+func (f format) matches(mediaRange string) bool {
+	if mediaRange == "*/*" || mediaRange == f.mediaType || slices.Contains(f.aliases, mediaRange) {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(f.mediaType, prefix+"/")
+	}
+	return false
+}
+
+type xmlList struct {
+	XMLName xml.Name `xml:"items"`
+	Items   any      `xml:"item"`
+}
+
+func encodeXML(w io.Writer, v any) error {
+	if reflect.ValueOf(v).Kind() == reflect.Slice {
+		v = xmlList{Items: v}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+func encodeCSV(w io.Writer, v any) error {
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Slice {
+		return fmt.Errorf("csv: cannot encode %T", v)
+	}
+
+	elem := items.Type().Elem()
+	header, fields := csvColumns(elem)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		record := make([]string, 0, len(header))
+		if fields == nil {
+			record = append(record, fmt.Sprint(item.Interface()))
+		}
+		for _, idx := range fields {
+			record = append(record, csvValue(item.Field(idx)))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String {
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, v.Index(i).String())
+		}
+		return strings.Join(values, "|")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func csvColumns(elem reflect.Type) ([]string, []int) {
+	if elem.Kind() != reflect.Struct {
+		return []string{"value"}, nil
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+	return header, fields
+}
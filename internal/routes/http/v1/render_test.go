@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSelectFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+		ok     bool
+	}{
+		{accept: "", want: "application/json", ok: true},
+		{accept: "*/*", want: "application/json", ok: true},
+		{accept: "text/csv", want: "text/csv", ok: true},
+		{accept: "text/*", want: "text/csv", ok: true},
+		{accept: "text/xml", want: "application/xml", ok: true},
+		{accept: "application/x-msgpack", want: "application/msgpack", ok: true},
+		{accept: "application/xml;q=0.5, text/csv", want: "text/csv", ok: true},
+		{accept: "text/csv;q=0, application/json;q=0.1", want: "application/json", ok: true},
+		{accept: "text/html, application/xml;q=0.9", want: "application/xml", ok: true},
+		{accept: "text/html", ok: false},
+		{accept: "text/csv;q=0", ok: false},
+	}
+
+	for _, tt := range tests {
+		f, ok := selectFormat(tt.accept, listFormats)
+		if ok != tt.ok || f.mediaType != tt.want {
+			t.Errorf("selectFormat(%q) = %q, %v, want %q, %v", tt.accept, f.mediaType, ok, tt.want, tt.ok)
+		}
+	}
+
+	if f, ok := selectFormat("text/csv", objectFormats); ok {
+		t.Errorf("selectFormat(text/csv) of a single resource = %q, want none", f.mediaType)
+	}
+}
+
+type renderedSong struct {
+	ID    int64    `json:"id" xml:"id"`
+	Group string   `json:"group" xml:"group"`
+	Tags  []string `json:"tags" xml:"tags>tag"`
+}
+
+func TestNegotiate(t *testing.T) {
+	songs := []renderedSong{{ID: 1, Group: "Muse", Tags: []string{"rock", "alt"}}}
+	handler := negotiate(listFormats)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, http.StatusOK, songs)
+	}))
+
+	tests := []struct {
+		accept      string
+		wantStatus  int
+		wantType    string
+		wantPayload string
+	}{
+		{
+			accept:      "application/json",
+			wantStatus:  http.StatusOK,
+			wantType:    "application/json",
+			wantPayload: `[{"id":1,"group":"Muse","tags":["rock","alt"]}]` + "\n",
+		},
+		{
+			accept:      "text/csv",
+			wantStatus:  http.StatusOK,
+			wantType:    "text/csv",
+			wantPayload: "id,group,tags\n1,Muse,rock|alt\n",
+		},
+		{
+			accept:      "application/xml",
+			wantStatus:  http.StatusOK,
+			wantType:    "application/xml",
+			wantPayload: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<items><item><id>1</id><group>Muse</group><tags><tag>rock</tag><tag>alt</tag></tags></item></items>`,
+		},
+		{
+			accept:     "image/png",
+			wantStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/songs", nil)
+		req.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("Accept %q: status = %d, want %d", tt.accept, rec.Code, tt.wantStatus)
+			continue
+		}
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+			t.Errorf("Accept %q: Content-Type = %q, want %q", tt.accept, got, tt.wantType)
+		}
+		if got := rec.Body.String(); got != tt.wantPayload {
+			t.Errorf("Accept %q: body = %q, want %q", tt.accept, got, tt.wantPayload)
+		}
+	}
+}
+
+func TestNegotiateMessagePack(t *testing.T) {
+	handler := negotiate(objectFormats)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, http.StatusOK, map[string]string{"group": "Muse"})
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/songs/1", nil)
+	req.Header.Set("Accept", "application/vnd.msgpack")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// A one-entry fixmap: "group" as a fixstr of 5, then "Muse" of 4.
+	want := append([]byte{0x81, 0xa5}, "group\xa4Muse"...)
+	if got := rec.Header().Get("Content-Type"); got != "application/msgpack" {
+		t.Errorf("Content-Type = %q, want application/msgpack", got)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), want) {
+		t.Errorf("body = %x, want %x", rec.Body.Bytes(), want)
+	}
+}
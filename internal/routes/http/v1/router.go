@@ -2,14 +2,15 @@ package v1
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/Zorynix/song-library/internal/entity"
 	errs "github.com/Zorynix/song-library/internal/errors"
 	logger "github.com/Zorynix/song-library/internal/logger"
 	"github.com/Zorynix/song-library/internal/services"
+	"github.com/Zorynix/song-library/internal/validation"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -21,114 +22,322 @@ import (
 
 type Handler struct {
 	services *services.Services
+	options  Options
 }
 
-func NewHandler(services *services.Services) *Handler {
-	return &Handler{services: services}
+type Options struct {
+	StatsMaxAge     time.Duration
+	SuggestMaxAge   time.Duration
+	EventsHeartbeat time.Duration
+}
+
+func NewHandler(services *services.Services, options Options) *Handler {
+	return &Handler{services: services, options: options}
 }
 
 func (h *Handler) Register(r chi.Router) {
-	r.Get("/songs", h.GetSongs)
-	r.Get("/songs/{id}/verses", h.GetSongVerses)
-	r.Delete("/songs/{id}", h.DeleteSong)
-	r.Put("/songs/{id}", h.UpdateSong)
-	r.Post("/songs", h.AddSong)
+	r.With(negotiate(listFormats)).Get("/songs", h.GetSongs)
+	r.With(negotiate(objectFormats)).Get("/songs/random", h.GetRandomSong)
+	r.With(negotiate(objectFormats)).Get("/songs/daily", h.GetDailySong)
+	r.With(negotiate(objectFormats)).Get("/songs/facets", h.GetSongFacets)
+	r.With(negotiate(listFormats)).Get("/songs/{id}/verses", h.GetSongVerses)
+	r.With(negotiate(objectFormats)).Get("/songs/{id}/verses/{n}", h.GetSongVerse)
+	r.With(negotiate(listFormats)).Get("/songs/{id}/similar", h.GetSimilarSongs)
+	r.With(h.idempotent).Delete("/songs/{id}", h.DeleteSong)
+	r.With(negotiate(objectFormats), h.idempotent).Put("/songs/{id}", h.UpdateSong)
+	r.With(negotiate(objectFormats), h.idempotent).Post("/songs", h.AddSong)
+	r.With(negotiate(objectFormats), h.idempotent).Post("/songs:bulk-delete", h.BulkDeleteSongs)
+	r.With(negotiate(objectFormats), h.idempotent).Post("/songs:bulk-update", h.BulkUpdateSongs)
+	r.With(negotiate(objectFormats)).Get("/search/lyrics", h.SearchLyrics)
+	r.With(negotiate(listFormats), cacheable(h.options.SuggestMaxAge)).Get("/suggest", h.Suggest)
+	r.Get("/events", h.StreamEvents)
+	r.With(negotiate(listFormats)).Get("/webhooks", h.GetWebhooks)
+	r.With(negotiate(objectFormats), h.idempotent).Post("/webhooks", h.AddWebhook)
+	r.With(negotiate(objectFormats)).Get("/webhooks/{id}", h.GetWebhook)
+	r.With(negotiate(objectFormats), h.idempotent).Put("/webhooks/{id}", h.UpdateWebhook)
+	r.With(h.idempotent).Delete("/webhooks/{id}", h.DeleteWebhook)
+	r.With(negotiate(objectFormats)).Get("/webhooks/{id}/deliveries", h.GetWebhookDeliveries)
+	r.With(negotiate(objectFormats)).Post("/webhooks/{id}/deliveries/{deliveryId}/redeliver", h.RedeliverWebhook)
+	r.With(negotiate(objectFormats), cacheable(h.options.StatsMaxAge)).Get("/stats", h.GetSongStats)
+	r.With(negotiate(listFormats), cacheable(h.options.StatsMaxAge)).Get("/stats/groups", h.GetSongStatsByGroup)
+	r.With(negotiate(listFormats), cacheable(h.options.StatsMaxAge)).Get("/stats/years", h.GetSongStatsByYear)
+	r.With(negotiate(listFormats), cacheable(h.options.StatsMaxAge)).Get("/stats/tags", h.GetSongStatsByTag)
+}
+
+func querySongFilter(params *paramParser) entity.SongFilter {
+	query := params.r.URL.Query()
+	return entity.SongFilter{
+		Group:    query.Get("group"),
+		Title:    query.Get("song"),
+		Text:     query.Get("text"),
+		Query:    query.Get("q"),
+		Language: query.Get("lang"),
+		Fuzzy:    params.queryBool("fuzzy"),
+		Filter:   query.Get("filter"),
+	}
 }
 
 // GetSongs возвращает список песен с фильтрацией
 // @Summary Получить список песен
-// @Description Возвращает список песен с возможностью фильтрации по группе, названию и тексту
+// @Description Возвращает список песен с возможностью фильтрации по группе, названию и тексту.
+// @Description Общее количество песен передаётся в заголовках X-Total-Count и Link,
+// @Description а при envelope=true ответ оборачивается в entity.Page.
+// @Description Если по group, song или q ничего не найдено, конверт содержит подсказки didYouMean.
 // @Tags Songs
 // @Accept json
-// @Produce json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
 // @Param group query string false "Название группы"
 // @Param song query string false "Название песни"
 // @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; результаты сортируются по релевантности"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток, сортируя по похожести"
 // @Param limit query int false "Лимит записей"
 // @Param offset query int false "Смещение"
+// @Param filter query string false "Выражение фильтра, например: group eq 'Muse' and (title contains 'hole' or releaseDate gt '2006-01-01')"
+// @Param fields query string false "Поля через запятую (id, group, title, releaseDate, text, link, tags); по умолчанию все, кроме text"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
 // @Success 200 {array} entity.Song "Список песен"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Header 200 {integer} X-Total-Count "Общее количество песен"
+// @Header 200 {string} Link "Ссылки на следующую и предыдущую страницы"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
 // @Router /songs [get]
 func (h *Handler) GetSongs(w http.ResponseWriter, r *http.Request) {
-	var filter entity.SongFilter
-	filter.Group = r.URL.Query().Get("group")
-	filter.Title = r.URL.Query().Get("song")
-	filter.Text = r.URL.Query().Get("text")
-	if limit := r.URL.Query().Get("limit"); limit != "" {
-		filter.Limit, _ = strconv.Atoi(limit)
-	}
-	if offset := r.URL.Query().Get("offset"); offset != "" {
-		filter.Offset, _ = strconv.Atoi(offset)
+	params := newParamParser(r)
+
+	filter := querySongFilter(params)
+	filter.Limit = params.queryInt("limit")
+	filter.Offset = params.queryInt("offset")
+	filter.Fields = queryFields(r, entity.DefaultSongListFields)
+
+	if err := params.err(func() error { return validation.SongFilter(filter) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSongs request parameters")
+		writeError(w, r, err)
+		return
 	}
 
 	logger.Logger.Debug().
 		Str("group", filter.Group).
 		Str("title", filter.Title).
 		Str("text", filter.Text).
+		Str("q", filter.Query).
 		Int("limit", filter.Limit).
 		Int("offset", filter.Offset).
+		Strs("fields", filter.Fields).
+		Str("filter", filter.Filter).
 		Msg("Handling GetSongs request")
 
-	songs, err := h.services.Song.GetSongs(r.Context(), filter)
+	songs, total, err := h.services.Song.GetSongs(r.Context(), filter)
 	if err != nil {
 		logger.Logger.Error().Err(err).Msg("Failed to handle GetSongs request")
-		http.Error(w, errs.ErrInternal.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+
+	page := entity.Page{Items: selectFields(songs, filter.Fields), Total: total, Limit: filter.Limit, Offset: filter.Offset}
+	if total == 0 && (filter.Group != "" || filter.Title != "" || filter.Query != "") {
+		if page.DidYouMean, err = h.services.Song.DidYouMean(r.Context(), filter); err != nil {
+			logger.Logger.Warn().Err(err).Msg("Failed to fetch did you mean suggestions")
+		}
+	}
+
+	logger.Logger.Info().Int("count", len(songs)).Int("total", total).Int("suggestions", len(page.DidYouMean)).Msg("GetSongs request handled successfully")
+	writeEnvelopedPage(w, r, page)
+}
+
+// GetRandomSong возвращает случайную песню
+// @Summary Получить случайную песню
+// @Description Возвращает случайную песню среди подходящих под те же фильтры, что и GET /songs.
+// @Tags Songs
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; случайная песня выбирается среди найденных"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток, сортируя по похожести"
+// @Param filter query string false "Выражение фильтра, например: group eq 'Muse'"
+// @Success 200 {object} entity.Song "Случайная песня"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 404 {object} Problem "Нет подходящих песен"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs/random [get]
+func (h *Handler) GetRandomSong(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	filter := querySongFilter(params)
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetRandomSong request parameters")
+		writeError(w, r, err)
 		return
 	}
 
-	logger.Logger.Info().Int("count", len(songs)).Msg("GetSongs request handled successfully")
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(songs)
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Msg("Handling GetRandomSong request")
+
+	song, err := h.services.Song.RandomSong(r.Context(), filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle GetRandomSong request")
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	logger.Logger.Info().Int64("id", song.ID).Msg("GetRandomSong request handled successfully")
+	respond(w, r, http.StatusOK, song)
+}
+
+// GetDailySong возвращает песню дня
+// @Summary Получить песню дня
+// @Description Возвращает песню дня: в один и тот же день в заданном часовом поясе все получают одну и ту же песню.
+// @Tags Songs
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param tz query string false "Часовой пояс IANA, например Europe/Moscow; по умолчанию часовой пояс сервера"
+// @Param date query string false "Дата в формате YYYY-MM-DD; по умолчанию сегодня"
+// @Success 200 {object} entity.Song "Песня дня"
+// @Failure 400 {object} Problem "Неверный часовой пояс или дата"
+// @Failure 404 {object} Problem "Библиотека пуста"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs/daily [get]
+func (h *Handler) GetDailySong(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	loc := params.queryLocation("tz")
+	day, ok := params.queryDate("date", loc)
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetDailySong request parameters")
+		writeError(w, r, err)
+		return
+	}
+	if !ok {
+		day = time.Now().In(loc)
+	}
+
+	logger.Logger.Debug().Str("date", day.Format(time.DateOnly)).Str("tz", loc.String()).Msg("Handling GetDailySong request")
+
+	song, err := h.services.Song.DailySong(r.Context(), day)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle GetDailySong request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", song.ID).Msg("GetDailySong request handled successfully")
+	respond(w, r, http.StatusOK, song)
 }
 
 // GetSongVerses возвращает куплеты песни по ID
 // @Summary Получить куплеты песни
-// @Description Возвращает куплеты песни по её ID с пагинацией
+// @Description Возвращает куплеты песни по её ID с пагинацией; каждый куплет содержит свой индекс.
+// @Description С unit=line пагинация идёт по строкам вместо куплетов.
+// @Description Общее количество куплетов передаётся в заголовках X-Total-Count и Link,
+// @Description а при envelope=true ответ оборачивается в entity.Page.
 // @Tags Songs
 // @Accept json
-// @Produce json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
 // @Param id path int true "ID песни"
 // @Param limit query int false "Лимит куплетов"
 // @Param offset query int false "Смещение"
-// @Success 200 {array} string "Список куплетов"
-// @Failure 400 {string} string "Неверный ID"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Param unit query string false "Единица пагинации: verse (по умолчанию) или line" Enums(verse, line)
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Success 200 {array} entity.Verse "Список куплетов"
+// @Header 200 {integer} X-Total-Count "Общее количество куплетов"
+// @Header 200 {string} Link "Ссылки на следующую и предыдущую страницы"
+// @Failure 400 {object} Problem "Неверный ID или параметры пагинации"
+// @Failure 404 {object} Problem "Песня не найдена"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
 // @Router /songs/{id}/verses [get]
 func (h *Handler) GetSongVerses(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	params := newParamParser(r)
+	id := params.pathID("id")
 
 	var pagination entity.VersePagination
 	pagination.SongID = id
-	if limit := r.URL.Query().Get("limit"); limit != "" {
-		pagination.Limit, _ = strconv.Atoi(limit)
-	}
-	if offset := r.URL.Query().Get("offset"); offset != "" {
-		pagination.Offset, _ = strconv.Atoi(offset)
+	pagination.Limit = params.queryInt("limit")
+	pagination.Offset = params.queryInt("offset")
+	pagination.Unit = entity.VerseUnit(r.URL.Query().Get("unit"))
+
+	if err := params.err(func() error { return validation.VersePagination(pagination) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSongVerses request parameters")
+		writeError(w, r, err)
+		return
 	}
 
 	logger.Logger.Debug().
 		Int64("song_id", pagination.SongID).
 		Int("limit", pagination.Limit).
 		Int("offset", pagination.Offset).
+		Str("unit", string(pagination.Unit)).
 		Msg("Handling GetSongVerses request")
 
-	verses, err := h.services.Song.GetSongVerses(r.Context(), pagination)
+	verses, total, err := h.services.Song.GetSongVerses(r.Context(), pagination)
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("song_id", id).Msg("Failed to handle GetSongVerses request")
-		if errors.Is(err, errs.ErrInvalidInput) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, errs.ErrInternal.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	logger.Logger.Info().
 		Int64("song_id", id).
 		Int("verse_count", len(verses)).
+		Int("total", total).
 		Msg("GetSongVerses request handled successfully")
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(verses)
+	writePage(w, r, verses, total, pagination.Limit, pagination.Offset)
+}
+
+// GetSongVerse возвращает один куплет песни
+// @Summary Получить куплет песни
+// @Description Возвращает куплет песни по его индексу (с нуля) вместе с общим количеством куплетов.
+// @Description С unit=line индекс указывает на строку.
+// @Tags Songs
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param id path int true "ID песни"
+// @Param n path int true "Индекс куплета, начиная с 0"
+// @Param unit query string false "Единица: verse (по умолчанию) или line" Enums(verse, line)
+// @Success 200 {object} entity.SongVerse "Куплет"
+// @Failure 400 {object} Problem "Неверный ID или индекс"
+// @Failure 404 {object} Problem "Песня или куплет не найдены"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs/{id}/verses/{n} [get]
+func (h *Handler) GetSongVerse(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	id := params.pathID("id")
+	index := params.pathInt("n")
+	unit := entity.VerseUnit(r.URL.Query().Get("unit"))
+
+	if err := params.err(func() error { return validation.Verse(id, unit, index) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSongVerse request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().Int64("song_id", id).Int("index", index).Str("unit", string(unit)).Msg("Handling GetSongVerse request")
+
+	verse, err := h.services.Song.GetSongVerse(r.Context(), id, unit, index)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", id).Int("index", index).Msg("Failed to handle GetSongVerse request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("song_id", id).Int("index", index).Msg("GetSongVerse request handled successfully")
+	respond(w, r, http.StatusOK, verse)
 }
 
 // DeleteSong удаляет песню по ID
@@ -137,29 +346,31 @@ func (h *Handler) GetSongVerses(w http.ResponseWriter, r *http.Request) {
 // @Tags Songs
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
 // @Param id path int true "ID песни"
 // @Success 204 {string} string "Песня успешно удалена"
-// @Failure 400 {string} string "Неверный ID"
-// @Failure 404 {string} string "Песня не найдена"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Failure 400 {object} Problem "Неверный ID"
+// @Failure 404 {object} Problem "Песня не найдена"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
 // @Router /songs/{id} [delete]
 func (h *Handler) DeleteSong(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	params := newParamParser(r)
+	id := params.pathID("id")
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid DeleteSong request parameters")
+		writeError(w, r, err)
+		return
+	}
 
 	logger.Logger.Debug().Int64("id", id).Msg("Handling DeleteSong request")
 
 	err := h.services.Song.DeleteSong(r.Context(), id)
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to handle DeleteSong request")
-		if errors.Is(err, errs.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if errors.Is(err, errs.ErrInvalidInput) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, errs.ErrInternal.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -172,49 +383,52 @@ func (h *Handler) DeleteSong(w http.ResponseWriter, r *http.Request) {
 // @Description Обновляет данные песни по её ID
 // @Tags Songs
 // @Accept json
-// @Produce json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
 // @Param id path int true "ID песни"
 // @Param song body entity.Song true "Данные песни"
 // @Success 200 {object} entity.Song "Обновленная песня"
-// @Failure 400 {string} string "Неверный запрос или ID"
-// @Failure 404 {string} string "Песня не найдена"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Failure 400 {object} Problem "Неверный запрос или ID"
+// @Failure 404 {object} Problem "Песня не найдена"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
 // @Router /songs/{id} [put]
 func (h *Handler) UpdateSong(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	params := newParamParser(r)
+	id := params.pathID("id")
 
 	var song entity.Song
 	if err := json.NewDecoder(r.Body).Decode(&song); err != nil {
 		logger.Logger.Error().Err(err).Msg("Failed to decode song data")
-		http.Error(w, errs.ErrInvalidInput.Error(), http.StatusBadRequest)
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
 		return
 	}
 	song.ID = id
 
+	if err := params.err(func() error { return validation.Song(song) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid UpdateSong request parameters")
+		writeError(w, r, err)
+		return
+	}
+
 	logger.Logger.Debug().
 		Int64("id", song.ID).
 		Str("group", song.Group).
 		Str("title", song.Title).
 		Msg("Handling UpdateSong request")
 
-	err := h.services.Song.UpdateSong(r.Context(), song)
+	updated, err := h.services.Song.UpdateSong(r.Context(), song)
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to handle UpdateSong request")
-		if errors.Is(err, errs.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if errors.Is(err, errs.ErrInvalidInput) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, errs.ErrInternal.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	logger.Logger.Info().Int64("id", id).Msg("UpdateSong request handled successfully")
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(song)
+	respond(w, r, http.StatusOK, updated)
 }
 
 // AddSong добавляет новую песню
@@ -222,17 +436,24 @@ func (h *Handler) UpdateSong(w http.ResponseWriter, r *http.Request) {
 // @Description Добавляет новую песню, обогащая её данными из внешнего API
 // @Tags Songs
 // @Accept json
-// @Produce json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
 // @Param song body entity.Song true "Данные песни (group и title обязательны)"
 // @Success 201 {object} entity.Song "Созданная песня"
-// @Failure 400 {string} string "Неверный запрос"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Failure 400 {object} Problem "Неверный запрос"
+// @Failure 404 {object} Problem "Песня не найдена во внешнем API"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Failure 502 {object} Problem "Ошибка внешнего API"
 // @Router /songs [post]
 func (h *Handler) AddSong(w http.ResponseWriter, r *http.Request) {
 	var song entity.Song
 	if err := json.NewDecoder(r.Body).Decode(&song); err != nil {
 		logger.Logger.Error().Err(err).Msg("Failed to decode song data")
-		http.Error(w, errs.ErrInvalidInput.Error(), http.StatusBadRequest)
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
 		return
 	}
 
@@ -244,12 +465,10 @@ func (h *Handler) AddSong(w http.ResponseWriter, r *http.Request) {
 	createdSong, err := h.services.Song.AddSong(r.Context(), song)
 	if err != nil {
 		logger.Logger.Error().Err(err).Str("group", song.Group).Str("title", song.Title).Msg("Failed to handle AddSong request")
-		http.Error(w, errs.ErrInternal.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	logger.Logger.Info().Int64("id", createdSong.ID).Msg("AddSong request handled successfully")
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(createdSong)
+	respond(w, r, http.StatusCreated, createdSong)
 }
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+// SearchLyrics ищет фрагмент в текстах песен
+// @Summary Поиск по текстам песен
+// @Description Возвращает песни, в тексте которых встречается q (без учёта регистра), с индексами куплетов
+// @Description и HTML-фрагментами вокруг совпадений, выделенных тегом <mark>. Индекс куплета можно передать
+// @Description как offset в GET /songs/{id}/verses. Пагинация идёт по песням; их общее количество
+// @Description передаётся в заголовках X-Total-Count и Link.
+// @Tags Search
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param q query string true "Искомый фрагмент текста"
+// @Param limit query int false "Лимит песен"
+// @Param offset query int false "Смещение"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Success 200 {array} entity.LyricMatch "Найденные песни"
+// @Header 200 {integer} X-Total-Count "Общее количество найденных песен"
+// @Header 200 {string} Link "Ссылки на следующую и предыдущую страницы"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /search/lyrics [get]
+func (h *Handler) SearchLyrics(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+
+	var search entity.LyricSearch
+	search.Query = r.URL.Query().Get("q")
+	search.Limit = params.queryInt("limit")
+	search.Offset = params.queryInt("offset")
+
+	if err := params.err(func() error { return validation.LyricSearch(search) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid SearchLyrics request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Str("query", search.Query).
+		Int("limit", search.Limit).
+		Int("offset", search.Offset).
+		Msg("Handling SearchLyrics request")
+
+	matches, total, err := h.services.Song.SearchLyrics(r.Context(), search)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle SearchLyrics request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("count", len(matches)).Int("total", total).Msg("SearchLyrics request handled successfully")
+	writePage(w, r, matches, total, search.Limit, search.Offset)
+}
+
+// Suggest подсказывает группы и названия песен по началу ввода
+// @Summary Автодополнение групп и названий
+// @Description Возвращает значения поля field, начинающиеся с prefix (без учёта регистра), в порядке убывания
+// @Description числа песен с этим значением. Рассчитан на вызов при каждом нажатии клавиши; ответ кэшируется
+// @Description (Cache-Control, ETag).
+// @Tags Search
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Param field query string true "Поле для подсказок" Enums(group, title)
+// @Param prefix query string true "Начало значения, не короче 2 символов"
+// @Param limit query int false "Количество подсказок (по умолчанию 10, не больше 20)"
+// @Param If-None-Match header string false "ETag ранее полученного ответа"
+// @Success 200 {array} entity.Completion "Подсказки"
+// @Success 304 "Подсказки не изменились"
+// @Header 200 {string} ETag "Тег версии ответа"
+// @Header 200 {string} Cache-Control "Время кэширования ответа"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /suggest [get]
+func (h *Handler) Suggest(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+
+	var completion entity.NameCompletion
+	completion.Field = r.URL.Query().Get("field")
+	completion.Prefix = r.URL.Query().Get("prefix")
+	completion.Limit = params.queryInt("limit")
+
+	if err := params.err(func() error { return validation.NameCompletion(completion) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid Suggest request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Str("field", completion.Field).
+		Str("prefix", completion.Prefix).
+		Int("limit", completion.Limit).
+		Msg("Handling Suggest request")
+
+	completions, err := h.services.Song.CompleteSongNames(r.Context(), completion)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle Suggest request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("count", len(completions)).Msg("Suggest request handled successfully")
+	respond(w, r, http.StatusOK, completions)
+}
+
+// GetSimilarSongs возвращает песни, похожие на данную
+// @Summary Похожие песни
+// @Description Возвращает песни с наиболее похожими текстами: сравниваются самые характерные для песни слова
+// @Description с учётом их редкости в библиотеке (TF-IDF). С boostGroup и boostTags выше поднимаются песни
+// @Description той же группы и с общими тегами. Score сравним только в пределах одного ответа.
+// @Tags Search
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Param id path int true "ID песни"
+// @Param limit query int false "Количество песен (по умолчанию 10)"
+// @Param boostGroup query bool false "Поднять песни той же группы"
+// @Param boostTags query bool false "Поднять песни с общими тегами"
+// @Success 200 {array} entity.SimilarSong "Похожие песни"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 404 {object} Problem "Песня не найдена"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs/{id}/similar [get]
+func (h *Handler) GetSimilarSongs(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+
+	var query entity.SimilarQuery
+	query.SongID = params.pathID("id")
+	query.Limit = params.queryInt("limit")
+	query.BoostGroup = params.queryBool("boostGroup")
+	query.BoostTags = params.queryBool("boostTags")
+
+	if err := params.err(func() error { return validation.SimilarQuery(query) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSimilarSongs request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Int64("song_id", query.SongID).
+		Int("limit", query.Limit).
+		Bool("boost_group", query.BoostGroup).
+		Bool("boost_tags", query.BoostTags).
+		Msg("Handling GetSimilarSongs request")
+
+	similar, err := h.services.Song.SimilarSongs(r.Context(), query)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", query.SongID).Msg("Failed to handle GetSimilarSongs request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("song_id", query.SongID).Int("count", len(similar)).Msg("GetSimilarSongs request handled successfully")
+	respond(w, r, http.StatusOK, similar)
+}
@@ -0,0 +1,261 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+// GetSongStats возвращает сводную статистику по песням
+// @Summary Получить статистику библиотеки
+// @Description Возвращает количество песен, средние число куплетов и длину текста,
+// @Description а также число песен без текста или ссылки. Учитывает те же фильтры, что и GET /songs.
+// @Description Ответ кэшируется (Cache-Control, ETag).
+// @Tags Stats
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток"
+// @Param filter query string false "Выражение фильтра, например: group eq 'Muse' and releaseDate gt '2006-01-01'"
+// @Param If-None-Match header string false "ETag ранее полученного ответа"
+// @Success 200 {object} entity.SongStats "Статистика"
+// @Success 304 "Статистика не изменилась"
+// @Header 200 {string} ETag "Тег версии ответа"
+// @Header 200 {string} Cache-Control "Время кэширования ответа"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /stats [get]
+func (h *Handler) GetSongStats(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	filter := querySongFilter(params)
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSongStats request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Msg("Handling GetSongStats request")
+
+	stats, err := h.services.Stats.GetSongStats(r.Context(), filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle GetSongStats request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("songs", stats.Songs).Msg("GetSongStats request handled successfully")
+	respond(w, r, http.StatusOK, stats)
+}
+
+// GetSongStatsByGroup возвращает количество песен по группам
+// @Summary Количество песен по группам
+// @Description Возвращает количество песен каждой группы по убыванию. Учитывает те же фильтры, что и GET /songs.
+// @Description Ответ кэшируется (Cache-Control, ETag).
+// @Tags Stats
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток"
+// @Param filter query string false "Выражение фильтра"
+// @Param limit query int false "Лимит записей (по умолчанию 20, не больше 100)"
+// @Param offset query int false "Смещение"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Param If-None-Match header string false "ETag ранее полученного ответа"
+// @Success 200 {array} entity.StatBucket "Количество песен по группам"
+// @Success 304 "Статистика не изменилась"
+// @Header 200 {integer} X-Total-Count "Общее количество групп"
+// @Header 200 {string} ETag "Тег версии ответа"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /stats/groups [get]
+func (h *Handler) GetSongStatsByGroup(w http.ResponseWriter, r *http.Request) {
+	h.countSongsBy(w, r, entity.StatsByGroup)
+}
+
+// GetSongStatsByYear возвращает количество песен по годам выпуска
+// @Summary Количество песен по годам выпуска
+// @Description Возвращает количество песен по годам выпуска в порядке возрастания года;
+// @Description песни с датой выпуска не в формате ДД.ММ.ГГГГ не учитываются. Учитывает те же фильтры, что и GET /songs.
+// @Description Ответ кэшируется (Cache-Control, ETag).
+// @Tags Stats
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток"
+// @Param filter query string false "Выражение фильтра"
+// @Param limit query int false "Лимит записей (по умолчанию 20, не больше 100)"
+// @Param offset query int false "Смещение"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Param If-None-Match header string false "ETag ранее полученного ответа"
+// @Success 200 {array} entity.StatBucket "Количество песен по годам"
+// @Success 304 "Статистика не изменилась"
+// @Header 200 {integer} X-Total-Count "Общее количество лет"
+// @Header 200 {string} ETag "Тег версии ответа"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /stats/years [get]
+func (h *Handler) GetSongStatsByYear(w http.ResponseWriter, r *http.Request) {
+	h.countSongsBy(w, r, entity.StatsByYear)
+}
+
+// GetSongStatsByTag возвращает количество песен по тегам
+// @Summary Количество песен по тегам
+// @Description Возвращает количество песен с каждым тегом по убыванию. Учитывает те же фильтры, что и GET /songs.
+// @Description Ответ кэшируется (Cache-Control, ETag).
+// @Tags Stats
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту; учитываются только найденные песни"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток"
+// @Param filter query string false "Выражение фильтра"
+// @Param limit query int false "Лимит записей (по умолчанию 20, не больше 100)"
+// @Param offset query int false "Смещение"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Param If-None-Match header string false "ETag ранее полученного ответа"
+// @Success 200 {array} entity.StatBucket "Количество песен по тегам"
+// @Success 304 "Статистика не изменилась"
+// @Header 200 {integer} X-Total-Count "Общее количество тегов"
+// @Header 200 {string} ETag "Тег версии ответа"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /stats/tags [get]
+func (h *Handler) GetSongStatsByTag(w http.ResponseWriter, r *http.Request) {
+	h.countSongsBy(w, r, entity.StatsByTag)
+}
+
+// GetSongFacets возвращает фасеты для фильтров списка песен
+// @Summary Фасеты списка песен
+// @Description Для песен, отобранных теми же фильтрами, что и GET /songs, возвращает количество песен
+// @Description по самым частым группам, годам выпуска и тегам — по убыванию количества. Поле total фасета —
+// @Description число различных значений. Все фасеты считаются по одному снимку данных.
+// @Tags Stats
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param text query string false "Текст песни"
+// @Param q query string false "Полнотекстовый запрос по названию, группе и тексту"
+// @Param lang query string false "Только песни на этом языке; запрос q разбирается по его правилам" Enums(ru, en)
+// @Param fuzzy query bool false "Искать группу и название с учётом опечаток"
+// @Param filter query string false "Выражение фильтра"
+// @Param facets query string false "Фасеты через запятую (group, year, tag); по умолчанию все"
+// @Param limit query int false "Количество значений в каждом фасете (по умолчанию 10)"
+// @Success 200 {array} entity.Facet "Фасеты"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /songs/facets [get]
+func (h *Handler) GetSongFacets(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+
+	var query entity.FacetQuery
+	query.Filter = querySongFilter(params)
+	query.Limit = params.queryInt("limit")
+	for _, dimension := range strings.Split(r.URL.Query().Get("facets"), ",") {
+		if dimension = strings.TrimSpace(dimension); dimension != "" {
+			query.Dimensions = append(query.Dimensions, entity.StatsDimension(dimension))
+		}
+	}
+
+	if err := params.err(func() error { return validation.FacetQuery(query) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetSongFacets request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Str("group", query.Filter.Group).
+		Str("title", query.Filter.Title).
+		Str("text", query.Filter.Text).
+		Str("q", query.Filter.Query).
+		Str("filter", query.Filter.Filter).
+		Int("dimensions", len(query.Dimensions)).
+		Int("limit", query.Limit).
+		Msg("Handling GetSongFacets request")
+
+	facets, err := h.services.Stats.SongFacets(r.Context(), query)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle GetSongFacets request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("facets", len(facets)).Msg("GetSongFacets request handled successfully")
+	respond(w, r, http.StatusOK, facets)
+}
+
+const defaultStatBuckets = 20
+
+func (h *Handler) countSongsBy(w http.ResponseWriter, r *http.Request, dimension entity.StatsDimension) {
+	params := newParamParser(r)
+
+	filter := querySongFilter(params)
+	filter.Limit = params.queryInt("limit")
+	filter.Offset = params.queryInt("offset")
+	if filter.Limit == 0 {
+		filter.Limit = defaultStatBuckets
+	}
+
+	if err := params.err(func() error { return validation.SongFilter(filter) }); err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg("Invalid CountSongsBy request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Str("dimension", string(dimension)).
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Handling CountSongsBy request")
+
+	buckets, total, err := h.services.Stats.CountSongsBy(r.Context(), filter, dimension)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg("Failed to handle CountSongsBy request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().
+		Str("dimension", string(dimension)).
+		Int("count", len(buckets)).
+		Int("total", total).
+		Msg("CountSongsBy request handled successfully")
+	writePage(w, r, buckets, total, filter.Limit, filter.Offset)
+}
@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+// GetWebhooks возвращает подписки на события
+// @Summary Получить список вебхуков
+// @Description Возвращает все подписки на события песен; секреты не возвращаются.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,text/csv,application/msgpack
+// @Produce application/problem+json
+// @Success 200 {array} entity.Webhook "Список вебхуков"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks [get]
+func (h *Handler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	logger.Logger.Debug().Msg("Handling GetWebhooks request")
+
+	webhooks, err := h.services.Webhook.GetWebhooks(r.Context())
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to handle GetWebhooks request")
+		writeError(w, r, err)
+		return
+	}
+
+	if webhooks == nil {
+		webhooks = []entity.Webhook{}
+	}
+	logger.Logger.Info().Int("count", len(webhooks)).Msg("GetWebhooks request handled successfully")
+	respond(w, r, http.StatusOK, webhooks)
+}
+
+// GetWebhook возвращает подписку по ID
+// @Summary Получить вебхук
+// @Description Возвращает подписку на события песен по её ID; секрет не возвращается.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param id path int true "ID вебхука"
+// @Success 200 {object} entity.Webhook "Вебхук"
+// @Failure 400 {object} Problem "Неверный ID"
+// @Failure 404 {object} Problem "Вебхук не найден"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{id} [get]
+func (h *Handler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	id := params.pathID("id")
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetWebhook request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().Int64("id", id).Msg("Handling GetWebhook request")
+
+	webhook, err := h.services.Webhook.GetWebhook(r.Context(), id)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to handle GetWebhook request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("GetWebhook request handled successfully")
+	respond(w, r, http.StatusOK, webhook)
+}
+
+// AddWebhook создаёт подписку на события
+// @Summary Создать вебхук
+// @Description Создаёт подписку на события song.created, song.updated и song.deleted.
+// @Description Доставки подписываются HMAC-SHA256 в заголовке X-Song-Library-Signature (t=<unix>,v1=<hex>)
+// @Description от строки "<t>.<тело запроса>". Если секрет не указан, он генерируется и возвращается только в этом ответе.
+// @Description Адрес должен быть публичным http(s)-адресом: loopback, частные и link-local адреса не принимаются.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
+// @Param webhook body entity.Webhook true "Данные вебхука (url и events обязательны)"
+// @Success 201 {object} entity.Webhook "Созданный вебхук с секретом"
+// @Failure 400 {object} Problem "Неверный запрос"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks [post]
+func (h *Handler) AddWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook := entity.Webhook{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode webhook data")
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
+		return
+	}
+
+	logger.Logger.Debug().Str("url", webhook.URL).Strs("events", webhook.Events).Msg("Handling AddWebhook request")
+
+	created, err := h.services.Webhook.AddWebhook(r.Context(), webhook)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("url", webhook.URL).Msg("Failed to handle AddWebhook request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", created.ID).Msg("AddWebhook request handled successfully")
+	respond(w, r, http.StatusCreated, created)
+}
+
+// UpdateWebhook обновляет подписку
+// @Summary Обновить вебхук
+// @Description Обновляет адрес, события и активность вебхука; пустой секрет оставляет прежний.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
+// @Param id path int true "ID вебхука"
+// @Param webhook body entity.Webhook true "Данные вебхука"
+// @Success 200 {object} entity.Webhook "Обновлённый вебхук"
+// @Failure 400 {object} Problem "Неверный запрос или ID"
+// @Failure 404 {object} Problem "Вебхук не найден"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{id} [put]
+func (h *Handler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	id := params.pathID("id")
+
+	webhook := entity.Webhook{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode webhook data")
+		writeError(w, r, fmt.Errorf("%w: %v", errs.ErrInvalidInput, err))
+		return
+	}
+	webhook.ID = id
+
+	if err := params.err(func() error { return validation.Webhook(webhook) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid UpdateWebhook request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().Int64("id", id).Str("url", webhook.URL).Msg("Handling UpdateWebhook request")
+
+	updated, err := h.services.Webhook.UpdateWebhook(r.Context(), webhook)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to handle UpdateWebhook request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("UpdateWebhook request handled successfully")
+	respond(w, r, http.StatusOK, updated)
+}
+
+// DeleteWebhook удаляет подписку
+// @Summary Удалить вебхук
+// @Description Удаляет вебхук вместе с историей его доставок
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param Idempotency-Key header string false "Ключ идемпотентности"
+// @Param id path int true "ID вебхука"
+// @Success 204 {string} string "Вебхук удалён"
+// @Failure 400 {object} Problem "Неверный ID"
+// @Failure 404 {object} Problem "Вебхук не найден"
+// @Failure 409 {object} Problem "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} Problem "Ключ идемпотентности использован с другим запросом"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	id := params.pathID("id")
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid DeleteWebhook request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().Int64("id", id).Msg("Handling DeleteWebhook request")
+
+	if err := h.services.Webhook.DeleteWebhook(r.Context(), id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to handle DeleteWebhook request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("DeleteWebhook request handled successfully")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries возвращает доставки вебхука
+// @Summary Получить доставки вебхука
+// @Description Возвращает доставки вебхука, начиная с последних. status=dead показывает доставки,
+// @Description исчерпавшие попытки, которые можно отправить повторно.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param id path int true "ID вебхука"
+// @Param status query string false "Статус доставки" Enums(pending, delivered, dead)
+// @Param limit query int false "Лимит записей"
+// @Param offset query int false "Смещение"
+// @Param envelope query bool false "Обернуть ответ в конверт пагинации"
+// @Success 200 {array} entity.WebhookDelivery "Список доставок"
+// @Header 200 {integer} X-Total-Count "Общее количество доставок"
+// @Header 200 {string} Link "Ссылки на следующую и предыдущую страницы"
+// @Failure 400 {object} Problem "Неверные параметры запроса"
+// @Failure 404 {object} Problem "Вебхук не найден"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *Handler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+
+	var filter entity.DeliveryFilter
+	filter.WebhookID = params.pathID("id")
+	filter.Status = entity.DeliveryStatus(r.URL.Query().Get("status"))
+	filter.Limit = params.queryInt("limit")
+	filter.Offset = params.queryInt("offset")
+
+	if err := params.err(func() error { return validation.DeliveryFilter(filter) }); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid GetWebhookDeliveries request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().
+		Int64("webhook_id", filter.WebhookID).
+		Str("status", string(filter.Status)).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Handling GetWebhookDeliveries request")
+
+	deliveries, total, err := h.services.Webhook.GetDeliveries(r.Context(), filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("webhook_id", filter.WebhookID).Msg("Failed to handle GetWebhookDeliveries request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int("count", len(deliveries)).Int("total", total).Msg("GetWebhookDeliveries request handled successfully")
+	writePage(w, r, deliveries, total, filter.Limit, filter.Offset)
+}
+
+// RedeliverWebhook отправляет доставку повторно
+// @Summary Повторить доставку вебхука
+// @Description Ставит доставку обратно в очередь с новым набором попыток.
+// @Tags Webhooks
+// @Accept json
+// @Produce json,application/xml,application/msgpack
+// @Produce application/problem+json
+// @Param id path int true "ID вебхука"
+// @Param deliveryId path int true "ID доставки"
+// @Success 202 {object} entity.WebhookDelivery "Доставка поставлена в очередь"
+// @Failure 400 {object} Problem "Неверный ID"
+// @Failure 404 {object} Problem "Доставка не найдена"
+// @Failure 406 {object} Problem "Неподдерживаемый формат ответа"
+// @Failure 500 {object} Problem "Внутренняя ошибка сервера"
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *Handler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	params := newParamParser(r)
+	webhookID := params.pathID("id")
+	deliveryID := params.pathID("deliveryId")
+	if err := params.err(nil); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid RedeliverWebhook request parameters")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Debug().Int64("webhook_id", webhookID).Int64("id", deliveryID).Msg("Handling RedeliverWebhook request")
+
+	delivery, err := h.services.Webhook.Redeliver(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", deliveryID).Msg("Failed to handle RedeliverWebhook request")
+		writeError(w, r, err)
+		return
+	}
+
+	logger.Logger.Info().Int64("id", deliveryID).Msg("RedeliverWebhook request handled successfully")
+	respond(w, r, http.StatusAccepted, delivery)
+}
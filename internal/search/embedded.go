@@ -0,0 +1,473 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+)
+
+const (
+	snapshotFile = "index.gob"
+	journalFile  = "journal.jsonl"
+	lockFileName = "LOCK"
+
+	// minCompactEntries is the shortest journal worth compacting.
+	minCompactEntries = 1000
+	maxJournalLine    = 16 << 20
+	prefixMatchFactor = 0.5
+)
+
+// EmbeddedIndex is an in-memory inverted index persisted as a snapshot plus a journal.
+type EmbeddedIndex struct {
+	mu        sync.RWMutex
+	dir       string
+	lock      *os.File
+	journal   *os.File
+	journaled int
+	isNew     bool
+	release   func() error
+
+	// pending collects changes made during a rebuild.
+	rebuildMu sync.Mutex
+	pending   []journalEntry
+
+	postings map[string]map[int64]float64
+	songs    map[int64]indexedSong
+	// terms are the sorted words of postings, for prefix matching.
+	terms []string
+}
+
+type indexedSong struct {
+	language string
+	terms    []string
+}
+
+type snapshot struct {
+	Postings  map[string]map[int64]float64
+	Languages map[int64]string
+}
+
+type journalEntry struct {
+	Put    *journaledSong `json:"put,omitempty"`
+	Delete []int64        `json:"delete,omitempty"`
+}
+
+type journaledSong struct {
+	ID       int64              `json:"id"`
+	Language string             `json:"language"`
+	Terms    map[string]float64 `json:"terms"`
+}
+
+func OpenEmbeddedIndex(dir string) (*EmbeddedIndex, error) {
+	logger.Logger.Debug().Str("dir", dir).Msg("Opening embedded search index")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+
+	lock, err := os.OpenFile(filepath.Join(dir, lockFileName), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, fmt.Errorf("%w: %s: %v", ErrIndexLocked, dir, err)
+	}
+
+	idx := &EmbeddedIndex{
+		dir:      dir,
+		lock:     lock,
+		postings: make(map[string]map[int64]float64),
+		songs:    make(map[int64]indexedSong),
+	}
+	if err := idx.load(); err != nil {
+		unlockFile(lock)
+		lock.Close()
+		return nil, err
+	}
+
+	logger.Logger.Info().
+		Str("dir", dir).
+		Int("songs", len(idx.songs)).
+		Int("terms", len(idx.terms)).
+		Int("journaled", idx.journaled).
+		Msg("Embedded search index opened successfully")
+	return idx, nil
+}
+
+// IsNew reports whether dir held no index when it was opened.
+func (idx *EmbeddedIndex) IsNew() bool {
+	return idx.isNew
+}
+
+func (idx *EmbeddedIndex) load() error {
+	data, err := os.ReadFile(filepath.Join(idx.dir, snapshotFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		idx.isNew = true
+	case err != nil:
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	default:
+		var snap snapshot
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snap); err != nil {
+			return fmt.Errorf("%w: corrupt snapshot: %v", ErrIndexFailed, err)
+		}
+		idx.restore(snap)
+	}
+
+	journal, err := os.OpenFile(filepath.Join(idx.dir, journalFile), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	idx.journal = journal
+
+	if err := idx.replay(); err != nil {
+		journal.Close()
+		return err
+	}
+	if idx.journaled > 0 {
+		idx.isNew = false
+	}
+	idx.sortTerms()
+	return nil
+}
+
+func (idx *EmbeddedIndex) restore(snap snapshot) {
+	terms := make(map[int64][]string, len(snap.Languages))
+	for term, songs := range snap.Postings {
+		for id := range songs {
+			terms[id] = append(terms[id], term)
+		}
+	}
+	idx.postings = snap.Postings
+	if idx.postings == nil {
+		idx.postings = make(map[string]map[int64]float64)
+	}
+	for id, language := range snap.Languages {
+		idx.songs[id] = indexedSong{language: language, terms: terms[id]}
+	}
+}
+
+// replay applies the journal; a last entry cut short by a crash is dropped.
+func (idx *EmbeddedIndex) replay() error {
+	scanner := bufio.NewScanner(idx.journal)
+	scanner.Buffer(nil, maxJournalLine)
+	var valid int64
+	for scanner.Scan() {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logger.Logger.Warn().Err(err).Int64("offset", valid).Msg("Dropping torn search index journal entry")
+			if err := idx.journal.Truncate(valid); err != nil {
+				return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+			}
+			return nil
+		}
+		idx.apply(entry)
+		idx.journaled++
+		valid += int64(len(scanner.Bytes())) + 1
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	return nil
+}
+
+func (idx *EmbeddedIndex) apply(entry journalEntry) {
+	if entry.Put != nil {
+		idx.remove(entry.Put.ID)
+		terms := make([]string, 0, len(entry.Put.Terms))
+		for term, weight := range entry.Put.Terms {
+			songs, ok := idx.postings[term]
+			if !ok {
+				songs = make(map[int64]float64)
+				idx.postings[term] = songs
+				idx.terms = nil
+			}
+			songs[entry.Put.ID] = weight
+			terms = append(terms, term)
+		}
+		idx.songs[entry.Put.ID] = indexedSong{language: entry.Put.Language, terms: terms}
+	}
+	for _, id := range entry.Delete {
+		idx.remove(id)
+	}
+}
+
+func (idx *EmbeddedIndex) remove(id int64) {
+	song, ok := idx.songs[id]
+	if !ok {
+		return
+	}
+	for _, term := range song.terms {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+			idx.terms = nil
+		}
+	}
+	delete(idx.songs, id)
+}
+
+func (idx *EmbeddedIndex) sortTerms() {
+	if idx.terms != nil {
+		return
+	}
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	slices.Sort(idx.terms)
+}
+
+func (idx *EmbeddedIndex) Put(ctx context.Context, songs ...entity.Song) error {
+	entries := make([]journalEntry, 0, len(songs))
+	for _, song := range songs {
+		entries = append(entries, journalEntry{Put: &journaledSong{ID: song.ID, Language: song.Language, Terms: songTerms(song)}})
+	}
+	return idx.record(entries)
+}
+
+func (idx *EmbeddedIndex) Delete(ctx context.Context, ids ...int64) error {
+	return idx.record([]journalEntry{{Delete: ids}})
+}
+
+// record journals entries before applying them.
+func (idx *EmbeddedIndex) record(entries []journalEntry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if _, err := idx.journal.Write(buf.Bytes()); err != nil {
+		logger.Logger.Error().Err(err).Msg(ErrIndexFailed.Error())
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := idx.journal.Sync(); err != nil {
+		logger.Logger.Error().Err(err).Msg(ErrIndexFailed.Error())
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+
+	for _, entry := range entries {
+		idx.apply(entry)
+	}
+	if idx.pending != nil {
+		idx.pending = append(idx.pending, entries...)
+	}
+	idx.journaled += len(entries)
+	idx.sortTerms()
+
+	if idx.pending == nil && idx.journaled >= max(minCompactEntries, len(idx.songs)) {
+		if err := idx.compact(); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to compact search index journal")
+		}
+	}
+	return nil
+}
+
+func (idx *EmbeddedIndex) compact() error {
+	snap := snapshot{Postings: idx.postings, Languages: make(map[int64]string, len(idx.songs))}
+	for id, song := range idx.songs {
+		snap.Languages[id] = song.language
+	}
+
+	path := filepath.Join(idx.dir, snapshotFile)
+	tmp, err := os.CreateTemp(idx.dir, snapshotFile+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+
+	if err := idx.journal.Truncate(0); err != nil {
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	idx.journaled = 0
+
+	logger.Logger.Info().Int("songs", len(idx.songs)).Int("terms", len(idx.postings)).Msg("Search index snapshot written")
+	return nil
+}
+
+func (idx *EmbeddedIndex) Search(ctx context.Context, query Query) ([]Hit, error) {
+	logger.Logger.Debug().Str("query", query.Text).Str("lang", query.Language).Msg("Searching songs in embedded index")
+
+	include, exclude := parseQuery(query.Text)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var scores map[int64]float64
+	for _, word := range include {
+		matched := make(map[int64]float64)
+		for term, factor := range idx.match(word) {
+			songs := idx.postings[term]
+			idf := math.Log(1 + (float64(len(idx.songs))-float64(len(songs))+0.5)/(float64(len(songs))+0.5))
+			for id, weight := range songs {
+				if scores != nil {
+					if _, ok := scores[id]; !ok {
+						continue
+					}
+				}
+				matched[id] = max(matched[id], factor*idf*weight/(weight+1))
+			}
+		}
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for id := range scores {
+			if score, ok := matched[id]; ok {
+				scores[id] += score
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	for _, word := range exclude {
+		for term := range idx.match(word) {
+			for id := range idx.postings[term] {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		if query.Language == "" || idx.songs[id].language == query.Language {
+			hits = append(hits, Hit{ID: id, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+
+	logger.Logger.Info().Int("hits", len(hits)).Msg("Songs searched successfully in embedded index")
+	return hits, nil
+}
+
+func (idx *EmbeddedIndex) match(word string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := idx.postings[word]; ok {
+		matches[word] = 1
+	}
+	if utf8.RuneCountInString(word) >= minPrefixLength {
+		for i := sort.SearchStrings(idx.terms, word); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], word); i++ {
+			if idx.terms[i] != word {
+				matches[idx.terms[i]] = prefixMatchFactor
+			}
+		}
+	}
+	return matches
+}
+
+// Rebuild builds a new index before replacing this one; changes made
+// meanwhile are applied to both.
+func (idx *EmbeddedIndex) Rebuild(ctx context.Context, songs iter.Seq2[entity.Song, error]) error {
+	logger.Logger.Debug().Str("dir", idx.dir).Msg("Rebuilding embedded search index")
+
+	idx.rebuildMu.Lock()
+	defer idx.rebuildMu.Unlock()
+
+	idx.mu.Lock()
+	idx.pending = []journalEntry{}
+	idx.mu.Unlock()
+	defer func() {
+		idx.mu.Lock()
+		idx.pending = nil
+		idx.mu.Unlock()
+	}()
+
+	fresh := &EmbeddedIndex{
+		postings: make(map[string]map[int64]float64),
+		songs:    make(map[int64]indexedSong),
+	}
+	for song, err := range songs {
+		if err != nil {
+			logger.Logger.Error().Err(err).Msg(ErrIndexFailed.Error())
+			return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+		}
+		fresh.apply(journalEntry{Put: &journaledSong{ID: song.ID, Language: song.Language, Terms: songTerms(song)}})
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, entry := range idx.pending {
+		fresh.apply(entry)
+	}
+	idx.postings, idx.songs, idx.terms = fresh.postings, fresh.songs, nil
+	idx.sortTerms()
+	if err := idx.compact(); err != nil {
+		logger.Logger.Error().Err(err).Msg(ErrIndexFailed.Error())
+		return err
+	}
+	idx.isNew = false
+
+	logger.Logger.Info().Int("songs", len(idx.songs)).Int("terms", len(idx.terms)).Msg("Embedded search index rebuilt successfully")
+	return nil
+}
+
+func (idx *EmbeddedIndex) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	err := idx.journal.Close()
+	unlockFile(idx.lock)
+	err = errors.Join(err, idx.lock.Close())
+	if idx.release != nil {
+		err = errors.Join(err, idx.release())
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+var testSongs = []entity.Song{
+	{ID: 1, Group: "Muse", Title: "Supermassive Black Hole", Text: "Ooh baby, don't you know I suffer?", Language: "en"},
+	{ID: 2, Group: "Muse", Title: "Uprising", Text: "They will not force us, they will stop degrading us", Language: "en"},
+	{ID: 3, Group: "Кино", Title: "Группа крови", Text: "Тёплое место, но улицы ждут", Language: "ru"},
+	{ID: 4, Group: "Hole", Title: "Celebrity Skin", Text: "Oh make me over, a black dress", Language: "en"},
+}
+
+func openTestIndex(t *testing.T, dir string) *EmbeddedIndex {
+	t.Helper()
+	idx, err := OpenEmbeddedIndex(dir)
+	if err != nil {
+		t.Fatalf("OpenEmbeddedIndex() error = %v", err)
+	}
+	return idx
+}
+
+func searchIDs(t *testing.T, idx *EmbeddedIndex, query Query) []int64 {
+	t.Helper()
+	hits, err := idx.Search(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Search(%+v) error = %v", query, err)
+	}
+	ids := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func TestEmbeddedIndexSearch(t *testing.T) {
+	idx := openTestIndex(t, t.TempDir())
+	defer idx.Close()
+	if err := idx.Put(context.Background(), testSongs...); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []int64
+	}{
+		{name: "title before lyrics", query: Query{Text: "black"}, want: []int64{1, 4}},
+		{name: "title before group", query: Query{Text: "hole"}, want: []int64{1, 4}},
+		{name: "every word", query: Query{Text: "muse black"}, want: []int64{1}},
+		{name: "excluded word", query: Query{Text: "muse -black"}, want: []int64{2}},
+		{name: "prefix", query: Query{Text: "degrad"}, want: []int64{2}},
+		{name: "short words need the whole word", query: Query{Text: "upr"}, want: []int64{}},
+		{name: "ё and case folded", query: Query{Text: "ТЕПЛОЕ"}, want: []int64{3}},
+		{name: "language", query: Query{Text: "black", Language: "ru"}, want: []int64{}},
+		{name: "limit", query: Query{Text: "black", Limit: 1}, want: []int64{1}},
+		{name: "no words", query: Query{Text: "-"}, want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchIDs(t, idx, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query.Text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedIndexReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	idx := openTestIndex(t, dir)
+	if !idx.IsNew() {
+		t.Errorf("IsNew() of an empty directory = false")
+	}
+	if err := idx.Put(ctx, testSongs...); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	renamed := testSongs[1]
+	renamed.Title = "Starlight"
+	if err := idx.Put(ctx, renamed); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := idx.Delete(ctx, 4); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := idx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// A crash while appending leaves a torn last entry, which is dropped.
+	journal, err := os.OpenFile(filepath.Join(dir, journalFile), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal.WriteString(`{"put":{"id":5,"lang`)
+	journal.Close()
+
+	idx = openTestIndex(t, dir)
+	defer idx.Close()
+	if idx.IsNew() {
+		t.Errorf("IsNew() of a journaled index = true")
+	}
+	for query, want := range map[string][]int64{
+		"starlight": {2},
+		"uprising":  {},
+		"black":     {1},
+	} {
+		if got := searchIDs(t, idx, Query{Text: query}); !slices.Equal(got, want) {
+			t.Errorf("after reopening, Search(%q) = %v, want %v", query, got, want)
+		}
+	}
+
+	// The torn entry is gone, so new entries are readable again.
+	if err := idx.Put(ctx, testSongs[3]); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	idx.Close()
+	idx = openTestIndex(t, dir)
+	defer idx.Close()
+	if got := searchIDs(t, idx, Query{Text: "celebrity"}); !slices.Equal(got, []int64{4}) {
+		t.Errorf("Search(celebrity) = %v, want [4]", got)
+	}
+}
+
+func songSeq(songs []entity.Song, err error) iter.Seq2[entity.Song, error] {
+	return func(yield func(entity.Song, error) bool) {
+		for _, song := range songs {
+			if !yield(song, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(entity.Song{}, err)
+		}
+	}
+}
+
+func TestEmbeddedIndexRebuild(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	idx := openTestIndex(t, dir)
+	if err := idx.Put(ctx, testSongs[:2]...); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// A failed read keeps the old index.
+	if err := idx.Rebuild(ctx, songSeq(testSongs[2:], errors.New("connection reset"))); !errors.Is(err, ErrIndexFailed) {
+		t.Errorf("Rebuild() error = %v, want %v", err, ErrIndexFailed)
+	}
+	if got := searchIDs(t, idx, Query{Text: "muse"}); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("after a failed rebuild, Search(muse) = %v, want [1 2]", got)
+	}
+
+	if err := idx.Rebuild(ctx, songSeq(testSongs[2:], nil)); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if got := searchIDs(t, idx, Query{Text: "muse"}); len(got) != 0 {
+		t.Errorf("after rebuilding, Search(muse) = %v, want none", got)
+	}
+	idx.Close()
+
+	// The rebuilt index is written as a snapshot with an empty journal.
+	if info, err := os.Stat(filepath.Join(dir, journalFile)); err != nil || info.Size() != 0 {
+		t.Errorf("journal after rebuild: %v, %v, want empty", info, err)
+	}
+	idx = openTestIndex(t, dir)
+	defer idx.Close()
+	if got := searchIDs(t, idx, Query{Text: "black"}); !slices.Equal(got, []int64{4}) {
+		t.Errorf("after reopening, Search(black) = %v, want [4]", got)
+	}
+}
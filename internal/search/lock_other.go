@@ -0,0 +1,14 @@
+//go:build !unix
+
+package search
+
+import "os"
+
+// Without flock the directory is not guarded; run one process per index.
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
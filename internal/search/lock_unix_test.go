@@ -0,0 +1,22 @@
+//go:build unix
+
+package search
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmbeddedIndexLocked(t *testing.T) {
+	dir := t.TempDir()
+	idx := openTestIndex(t, dir)
+
+	if _, err := OpenEmbeddedIndex(dir); !errors.Is(err, ErrIndexLocked) {
+		t.Errorf("second OpenEmbeddedIndex() error = %v, want %v", err, ErrIndexLocked)
+	}
+
+	// Closing the index frees the directory.
+	idx.Close()
+	idx = openTestIndex(t, dir)
+	idx.Close()
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/lyrics"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresIndex searches the generated search column of library.songs, so Put
+// and Delete have nothing to do.
+type PostgresIndex struct {
+	db *sqlx.DB
+}
+
+func NewPostgresIndex(db *sqlx.DB) *PostgresIndex {
+	return &PostgresIndex{db: db}
+}
+
+// searchConfigs mirror library.song_search_config.
+var searchConfigs = map[string]string{
+	lyrics.LanguageRussian: "library.songs_ru",
+	lyrics.LanguageEnglish: "library.songs_en",
+}
+
+// searchRankWeights weigh the D, C, B and A labels of the search column.
+const searchRankWeights = `'{0.1, 0.2, 0.4, 1.0}'`
+
+// tsQuery matches the words as stemmed by every configuration when no
+// language is given.
+func tsQuery(language string, param int) string {
+	if config, ok := searchConfigs[language]; ok {
+		return fmt.Sprintf("websearch_to_tsquery('%s', $%d::text)", config, param)
+	}
+	return fmt.Sprintf("(websearch_to_tsquery('%[1]s', $%[3]d::text) || websearch_to_tsquery('%[2]s', $%[3]d::text) || websearch_to_tsquery('simple', $%[3]d::text))",
+		searchConfigs[lyrics.LanguageRussian], searchConfigs[lyrics.LanguageEnglish], param)
+}
+
+func postgresMatch(language string, param int) (condition, rank string) {
+	q := tsQuery(language, param)
+	return "search @@ " + q, fmt.Sprintf("ts_rank(%s, search, %s)", searchRankWeights, q)
+}
+
+func (i *PostgresIndex) Put(ctx context.Context, songs ...entity.Song) error {
+	return nil
+}
+
+func (i *PostgresIndex) Delete(ctx context.Context, ids ...int64) error {
+	return nil
+}
+
+func (i *PostgresIndex) Search(ctx context.Context, query Query) ([]Hit, error) {
+	logger.Logger.Debug().Str("query", query.Text).Str("lang", query.Language).Msg("Searching songs in Postgres")
+
+	condition, rank := postgresMatch(query.Language, 1)
+	sql := fmt.Sprintf(`
+		SELECT id, %s AS score
+		FROM library.songs
+		WHERE %s AND ($2::text = '' OR language = $2::text)
+		ORDER BY score DESC, id`, rank, condition)
+	args := []interface{}{query.Text, query.Language}
+	if query.Limit > 0 {
+		sql += " LIMIT $3"
+		args = append(args, query.Limit)
+	}
+
+	var hits []Hit
+	if err := i.db.SelectContext(ctx, &hits, sql, args...); err != nil {
+		logger.Logger.Error().Err(err).Msg(ErrSearchFailed.Error())
+		return nil, fmt.Errorf("%w: %v", ErrSearchFailed, err)
+	}
+
+	logger.Logger.Info().Int("hits", len(hits)).Msg("Songs searched successfully in Postgres")
+	return hits, nil
+}
+
+// Rebuild only reindexes the search column, which is always current.
+func (i *PostgresIndex) Rebuild(ctx context.Context, songs iter.Seq2[entity.Song, error]) error {
+	logger.Logger.Debug().Msg("Rebuilding Postgres search index")
+
+	if _, err := i.db.ExecContext(ctx, `REINDEX INDEX library.songs_search_idx`); err != nil {
+		logger.Logger.Error().Err(err).Msg(ErrIndexFailed.Error())
+		return fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+
+	logger.Logger.Info().Msg("Postgres search index rebuilt successfully")
+	return nil
+}
+
+func (i *PostgresIndex) Close() error {
+	return nil
+}
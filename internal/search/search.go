@@ -0,0 +1,96 @@
+// Package search maps full-text song queries to the ids of matching songs.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	BackendPostgres = "postgres"
+	BackendEmbedded = "embedded"
+)
+
+var (
+	ErrIndexFailed  = errors.New("failed to update search index")
+	ErrSearchFailed = errors.New("failed to search songs")
+	ErrIndexLocked  = errors.New("search index is in use by another process")
+)
+
+// Query is a web-search style query: words prefixed with "-" must not match.
+type Query struct {
+	Text     string
+	Language string
+	// Limit of zero means no limit.
+	Limit int
+}
+
+type Hit struct {
+	ID    int64   `db:"id"`
+	Score float64 `db:"score"`
+}
+
+// SearchIndex is kept current by SongService after every song change.
+type SearchIndex interface {
+	Put(ctx context.Context, songs ...entity.Song) error
+	Delete(ctx context.Context, ids ...int64) error
+	// Search returns hits most relevant first.
+	Search(ctx context.Context, query Query) ([]Hit, error)
+	Rebuild(ctx context.Context, songs iter.Seq2[entity.Song, error]) error
+	Close() error
+}
+
+// Open returns the index of backend. The embedded index only sees the changes
+// of its own process, so a second instance on the same database is refused.
+func Open(backend string, db *sqlx.DB, dir string) (SearchIndex, error) {
+	switch backend {
+	case BackendPostgres:
+		return NewPostgresIndex(db), nil
+	case BackendEmbedded:
+		release, err := lockInstance(db)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := OpenEmbeddedIndex(dir)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		idx.release = release
+		return idx, nil
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", backend)
+	}
+}
+
+const instanceLockKey = 0x736f6e67
+
+// lockInstance holds an advisory lock on a dedicated connection until the
+// returned func is called.
+func lockInstance(db *sqlx.DB) (func() error, error) {
+	ctx := context.Background()
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+
+	var locked bool
+	if err := conn.GetContext(ctx, &locked, `SELECT pg_try_advisory_lock($1)`, instanceLockKey); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", ErrIndexFailed, err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, fmt.Errorf("%w: another instance serves the embedded index of this database", ErrIndexLocked)
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, instanceLockKey)
+		return errors.Join(err, conn.Close())
+	}, nil
+}
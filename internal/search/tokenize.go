@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+// Field weights match searchRankWeights.
+const (
+	titleWeight = 1.0
+	groupWeight = 0.4
+	textWeight  = 0.2
+)
+
+// minPrefixLength is the shortest query word also matched as a prefix, in
+// place of stemming.
+const minPrefixLength = 4
+
+// tokenize splits text into lowercase words, folding ё into е and dropping
+// single letters.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, word := range words {
+		if utf8.RuneCountInString(word) > 1 {
+			terms = append(terms, strings.ReplaceAll(word, "ё", "е"))
+		}
+	}
+	return terms
+}
+
+func songTerms(song entity.Song) map[string]float64 {
+	terms := make(map[string]float64)
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{{song.Title, titleWeight}, {song.Group, groupWeight}, {song.Text, textWeight}} {
+		for _, term := range tokenize(field.text) {
+			terms[term] += field.weight
+		}
+	}
+	return terms
+}
+
+// parseQuery splits a query into the words a song must and must not contain.
+func parseQuery(text string) (include, exclude []string) {
+	for _, word := range strings.Fields(text) {
+		negated := strings.HasPrefix(word, "-")
+		for _, term := range tokenize(word) {
+			if negated {
+				exclude = append(exclude, term)
+			} else {
+				include = append(include, term)
+			}
+		}
+	}
+	return include, exclude
+}
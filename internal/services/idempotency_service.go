@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+)
+
+type idempotencyService struct {
+	repos *repo.Repositories
+	ttl   time.Duration
+	lease time.Duration
+}
+
+func NewIdempotencyService(repos *repo.Repositories, ttl, lease time.Duration) IdempotencyService {
+	return &idempotencyService{
+		repos: repos,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	logger.Logger.Debug().Str("key", key).Msg("Beginning idempotent request")
+
+	now := time.Now()
+	record, created, err := s.repos.Idempotency.Reserve(ctx, key, requestHash, now.Add(s.lease), now.Add(s.ttl))
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to reserve idempotency key in service")
+		return nil, errs.ErrInternal
+	}
+	if created {
+		return nil, nil
+	}
+
+	if record.RequestHash != requestHash {
+		logger.Logger.Warn().Str("key", key).Msg("Idempotency key reused with a different request")
+		return nil, errs.ErrKeyReused
+	}
+	if record.StatusCode == nil {
+		logger.Logger.Warn().Str("key", key).Msg("Idempotency key is still in progress")
+		return nil, errs.ErrKeyInProgress
+	}
+
+	logger.Logger.Info().Str("key", key).Int("status", *record.StatusCode).Msg("Replaying stored response in service")
+	return &record, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	if err := s.repos.Idempotency.Complete(ctx, key, statusCode, contentType, body); err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to store idempotent response in service")
+		return errs.ErrInternal
+	}
+	return nil
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	if err := s.repos.Idempotency.Release(ctx, key); err != nil {
+		logger.Logger.Error().Err(err).Str("key", key).Msg("Failed to release idempotency key in service")
+		return errs.ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+)
+
+type IdempotencySweeper struct {
+	repos    *repo.Repositories
+	interval time.Duration
+}
+
+func NewIdempotencySweeper(repos *repo.Repositories, interval time.Duration) *IdempotencySweeper {
+	return &IdempotencySweeper{repos: repos, interval: interval}
+}
+
+func (s *IdempotencySweeper) Run(ctx context.Context) {
+	logger.Logger.Info().Dur("interval", s.interval).Msg("Starting idempotency key sweeper")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Logger.Info().Msg("Idempotency key sweeper stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.repos.Idempotency.DeleteExpired(ctx); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to delete expired idempotency keys")
+		}
+	}
+}
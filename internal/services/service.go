@@ -2,30 +2,94 @@ package services
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/Zorynix/song-library/internal/musicinfo"
 	"github.com/Zorynix/song-library/internal/repo"
+	"github.com/Zorynix/song-library/internal/search"
 )
 
 type SongService interface {
-	GetSongs(ctx context.Context, filter entity.SongFilter) ([]entity.Song, error)
-	GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]string, error)
+	GetSongs(ctx context.Context, filter entity.SongFilter) ([]entity.Song, int, error)
+	// StreamSongs yields every matching song in id order without counting them.
+	StreamSongs(ctx context.Context, filter entity.SongFilter) (iter.Seq2[entity.Song, error], error)
+	RandomSong(ctx context.Context, filter entity.SongFilter) (entity.Song, error)
+	DailySong(ctx context.Context, day time.Time) (entity.Song, error)
+	GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]entity.Verse, int, error)
+	GetSongVerse(ctx context.Context, songID int64, unit entity.VerseUnit, index int) (entity.SongVerse, error)
+	GetVersesBySongIDs(ctx context.Context, ids []int64) (map[int64][]string, error)
+	SearchLyrics(ctx context.Context, search entity.LyricSearch) ([]entity.LyricMatch, int, error)
+	DidYouMean(ctx context.Context, filter entity.SongFilter) ([]entity.Suggestion, error)
+	CompleteSongNames(ctx context.Context, completion entity.NameCompletion) ([]entity.Completion, error)
+	SimilarSongs(ctx context.Context, query entity.SimilarQuery) ([]entity.SimilarSong, error)
 	DeleteSong(ctx context.Context, id int64) error
-	UpdateSong(ctx context.Context, song entity.Song) error
+	// UpdateSong returns the song as stored, with its language filled in.
+	UpdateSong(ctx context.Context, song entity.Song) (entity.Song, error)
 	AddSong(ctx context.Context, song entity.Song) (entity.Song, error)
+	BulkDeleteSongs(ctx context.Context, bulk entity.BulkDelete) (entity.BulkResult, error)
+	BulkUpdateSongs(ctx context.Context, bulk entity.BulkUpdate) (entity.BulkResult, error)
+}
+
+// IdempotencyService.Begin returns a stored record to replay, or nil when the
+// caller should execute the request and then Complete or Release the key.
+type IdempotencyService interface {
+	Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, key string) error
+}
+
+type StatsService interface {
+	GetSongStats(ctx context.Context, filter entity.SongFilter) (entity.SongStats, error)
+	CountSongsBy(ctx context.Context, filter entity.SongFilter, dimension entity.StatsDimension) ([]entity.StatBucket, int, error)
+	SongFacets(ctx context.Context, query entity.FacetQuery) ([]entity.Facet, error)
+}
+
+type SongEventStream interface {
+	Subscribe(lastEventID string, groups []string) (*SongEventSubscription, error)
+}
+
+type WebhookService interface {
+	GetWebhooks(ctx context.Context) ([]entity.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (entity.Webhook, error)
+	AddWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, filter entity.DeliveryFilter) ([]entity.WebhookDelivery, int, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int64) (entity.WebhookDelivery, error)
 }
 
 type Services struct {
-	Song SongService
+	Song        SongService
+	Idempotency IdempotencyService
+	Stats       StatsService
+	Webhook     WebhookService
+	Events      SongEventStream
 }
 
 type ServicesDependencies struct {
-	Repos       *repo.Repositories
-	MusicAPIURL string
+	Repos            *repo.Repositories
+	MusicInfo        musicinfo.MusicInfoProvider
+	IdempotencyTTL   time.Duration
+	IdempotencyLease time.Duration
+	Events           *SongEventBroadcaster
+	Search           search.SearchIndex
+	BulkMaxRows      int
+	FuzzyThreshold   float64
 }
 
 func NewServices(deps ServicesDependencies) *Services {
 	return &Services{
-		Song: NewSongService(deps.Repos, deps.MusicAPIURL),
+		Song: NewSongService(deps.Repos, deps.Search, deps.MusicInfo, SongServiceOptions{
+			BulkMaxRows:    deps.BulkMaxRows,
+			FuzzyThreshold: deps.FuzzyThreshold,
+		}),
+		Idempotency: NewIdempotencyService(deps.Repos, deps.IdempotencyTTL, deps.IdempotencyLease),
+		Stats: NewStatsService(deps.Repos, deps.Search, StatsServiceOptions{
+			FuzzyThreshold: deps.FuzzyThreshold,
+		}),
+		Webhook: NewWebhookService(deps.Repos),
+		Events:  deps.Events,
 	}
 }
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+func (s *songService) BulkDeleteSongs(ctx context.Context, bulk entity.BulkDelete) (entity.BulkResult, error) {
+	logger.Logger.Debug().Int("ids", len(bulk.IDs)).Bool("dry_run", bulk.DryRun).Msg("Bulk deleting songs")
+
+	if err := validation.BulkDelete(bulk); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid bulk delete in service")
+		return entity.BulkResult{}, err
+	}
+	selector, err := s.prepareSongSelector(ctx, bulk.SongSelector)
+	if err != nil {
+		return entity.BulkResult{}, err
+	}
+
+	matched, deleted, err := s.repos.Song.BulkDeleteSongs(ctx, selector, s.options.BulkMaxRows, bulk.DryRun, songEvent(entity.SongDeleted))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to bulk delete songs in service")
+		return entity.BulkResult{}, s.bulkError(err)
+	}
+
+	result := bulkResult(selector, matched, deleted, entity.BulkDeleted, bulk.DryRun)
+	logger.Logger.Info().Int("matched", result.Matched).Int("deleted", result.Changed).Bool("dry_run", bulk.DryRun).Msg("Songs bulk deleted successfully in service")
+
+	if !bulk.DryRun {
+		s.unindexSongs(ctx, songIDs(deleted)...)
+	}
+	return result, nil
+}
+
+func (s *songService) BulkUpdateSongs(ctx context.Context, bulk entity.BulkUpdate) (entity.BulkResult, error) {
+	logger.Logger.Debug().Int("ids", len(bulk.IDs)).Bool("dry_run", bulk.DryRun).Msg("Bulk updating songs")
+
+	if err := validation.BulkUpdate(bulk); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid bulk update in service")
+		return entity.BulkResult{}, err
+	}
+	selector, err := s.prepareSongSelector(ctx, bulk.SongSelector)
+	if err != nil {
+		return entity.BulkResult{}, err
+	}
+
+	matched, updated, err := s.repos.Song.BulkUpdateSongs(ctx, selector, bulk.Set, s.options.BulkMaxRows, bulk.DryRun, songEvent(entity.SongUpdated))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to bulk update songs in service")
+		return entity.BulkResult{}, s.bulkError(err)
+	}
+
+	result := bulkResult(selector, matched, updated, entity.BulkUpdated, bulk.DryRun)
+	logger.Logger.Info().Int("matched", result.Matched).Int("updated", result.Changed).Bool("dry_run", bulk.DryRun).Msg("Songs bulk updated successfully in service")
+
+	if !bulk.DryRun {
+		s.reindexSongs(ctx, updated)
+	}
+	return result, nil
+}
+
+func (s *songService) prepareSongSelector(ctx context.Context, selector entity.SongSelector) (entity.SongSelector, error) {
+	if selector.Filter != nil {
+		filter := *selector.Filter
+		if err := s.filters.prepare(ctx, &filter); err != nil {
+			return entity.SongSelector{}, err
+		}
+		selector.Filter = &filter
+	}
+	return selector, nil
+}
+
+// reindexSongs reads the lyrics the bulk update did not return.
+func (s *songService) reindexSongs(ctx context.Context, songs []entity.Song) {
+	if len(songs) == 0 {
+		return
+	}
+	texts, err := s.repos.Song.GetSongTexts(context.WithoutCancel(ctx), songIDs(songs))
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to fetch lyrics to index in service")
+		return
+	}
+	indexed := make([]entity.Song, len(songs))
+	for i, song := range songs {
+		song.Text = texts[song.ID]
+		indexed[i] = song
+	}
+	s.indexSongs(ctx, indexed...)
+}
+
+func songIDs(songs []entity.Song) []int64 {
+	ids := make([]int64, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+	return ids
+}
+
+func (s *songService) bulkError(err error) error {
+	if errors.Is(err, repoerrs.ErrTooManyRows) {
+		return fmt.Errorf("%w: the selection matches more than %d songs", errs.ErrBulkLimitExceeded, s.options.BulkMaxRows)
+	}
+	return errs.ErrInternal
+}
+
+func bulkResult(selector entity.SongSelector, matched []int64, changed []entity.Song, status entity.BulkStatus, dryRun bool) entity.BulkResult {
+	songs := make(map[int64]*entity.Song, len(changed))
+	for i := range changed {
+		songs[changed[i].ID] = &changed[i]
+	}
+	found := make(map[int64]bool, len(matched))
+	for _, id := range matched {
+		found[id] = true
+	}
+
+	requested := matched
+	if selector.Filter == nil {
+		requested = make([]int64, 0, len(selector.IDs))
+		seen := make(map[int64]bool, len(selector.IDs))
+		for _, id := range selector.IDs {
+			if !seen[id] {
+				seen[id] = true
+				requested = append(requested, id)
+			}
+		}
+	}
+
+	items := make([]entity.BulkItem, 0, len(requested))
+	for _, id := range requested {
+		item := entity.BulkItem{ID: id, Status: entity.BulkUnchanged}
+		switch {
+		case !found[id]:
+			item.Status = entity.BulkNotFound
+		case songs[id] != nil:
+			item.Status = status
+			item.Song = songs[id]
+		}
+		items = append(items, item)
+	}
+
+	return entity.BulkResult{
+		DryRun:  dryRun,
+		Matched: len(matched),
+		Changed: len(changed),
+		Items:   items,
+	}
+}
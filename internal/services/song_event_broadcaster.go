@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+)
+
+const (
+	subscriptionBuffer  = 64
+	listenRetryInterval = 5 * time.Second
+)
+
+// SongEventBroadcaster fans the song events notified by every instance out to
+// stream subscribers and keeps the last of them for resuming streams.
+type SongEventBroadcaster struct {
+	repos       *repo.Repositories
+	historySize int
+
+	mu          sync.Mutex
+	history     []entity.SongEvent
+	subscribers map[*SongEventSubscription]struct{}
+	closed      bool
+}
+
+func NewSongEventBroadcaster(repos *repo.Repositories, historySize int) *SongEventBroadcaster {
+	return &SongEventBroadcaster{
+		repos:       repos,
+		historySize: historySize,
+		subscribers: make(map[*SongEventSubscription]struct{}),
+	}
+}
+
+type SongEventSubscription struct {
+	Missed []entity.SongEvent
+	// Reset is set when the requested last event is no longer in the history.
+	Reset bool
+	// Events is closed when the subscriber falls too far behind.
+	Events <-chan entity.SongEvent
+
+	events      chan entity.SongEvent
+	groups      []string
+	broadcaster *SongEventBroadcaster
+}
+
+func (s *SongEventSubscription) matches(event entity.SongEvent) bool {
+	if len(s.groups) == 0 {
+		return true
+	}
+	if event.Song == nil {
+		return false
+	}
+	return slices.ContainsFunc(s.groups, func(group string) bool {
+		return strings.EqualFold(group, event.Song.Group)
+	})
+}
+
+func (s *SongEventSubscription) Close() {
+	b := s.broadcaster
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unsubscribe(s)
+}
+
+func (b *SongEventBroadcaster) Subscribe(lastEventID string, groups []string) (*SongEventSubscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		logger.Logger.Warn().Msg("Song event subscription rejected, broadcaster stopped")
+		return nil, errs.ErrUnavailable
+	}
+
+	events := make(chan entity.SongEvent, subscriptionBuffer)
+	sub := &SongEventSubscription{
+		Events:      events,
+		events:      events,
+		groups:      groups,
+		broadcaster: b,
+	}
+
+	if lastEventID != "" {
+		i := slices.IndexFunc(b.history, func(event entity.SongEvent) bool { return event.ID == lastEventID })
+		if i < 0 {
+			sub.Reset = true
+		} else {
+			for _, event := range b.history[i+1:] {
+				if sub.matches(event) {
+					sub.Missed = append(sub.Missed, event)
+				}
+			}
+		}
+	}
+
+	b.subscribers[sub] = struct{}{}
+	logger.Logger.Debug().
+		Str("last_event_id", lastEventID).
+		Strs("groups", groups).
+		Int("missed", len(sub.Missed)).
+		Bool("reset", sub.Reset).
+		Int("subscribers", len(b.subscribers)).
+		Msg("Song event subscription started")
+	return sub, nil
+}
+
+// unsubscribe must be called with mu held.
+func (b *SongEventBroadcaster) unsubscribe(sub *SongEventSubscription) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
+
+func (b *SongEventBroadcaster) Run(ctx context.Context) {
+	logger.Logger.Info().Int("history_size", b.historySize).Msg("Starting song event broadcaster")
+
+	for ctx.Err() == nil {
+		if err := b.repos.SongEvent.ListenSongEvents(ctx, b.receive); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to listen for song events, retrying")
+			select {
+			case <-ctx.Done():
+			case <-time.After(listenRetryInterval):
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		b.unsubscribe(sub)
+	}
+	logger.Logger.Info().Msg("Song event broadcaster stopped")
+}
+
+func (b *SongEventBroadcaster) receive(payload []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if payload == nil {
+		// Events may have been lost while reconnecting.
+		b.history = nil
+		for sub := range b.subscribers {
+			b.unsubscribe(sub)
+		}
+		return
+	}
+
+	var event entity.SongEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to decode song event")
+		return
+	}
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			b.history = slices.Delete(b.history, 0, 1)
+		}
+		b.history = append(b.history, event)
+	}
+
+	for sub := range b.subscribers {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			logger.Logger.Warn().Str("event", event.ID).Msg("Song event subscriber too slow, closing its stream")
+			b.unsubscribe(sub)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/search"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+type songFilters struct {
+	index          search.SearchIndex
+	fuzzyThreshold float64
+}
+
+// prepare validates the filter and resolves its full-text query into
+// filter.SearchIDs.
+func (f songFilters) prepare(ctx context.Context, filter *entity.SongFilter) error {
+	if err := validation.SongFilter(*filter); err != nil {
+		return err
+	}
+	filter.FuzzyThreshold = f.fuzzyThreshold
+	if filter.Query != "" {
+		hits, err := f.index.Search(ctx, search.Query{Text: filter.Query, Language: filter.Language})
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("q", filter.Query).Msg("Failed to search songs in service")
+			return errs.ErrInternal
+		}
+		filter.SearchIDs = make([]int64, 0, len(hits))
+		for _, hit := range hits {
+			filter.SearchIDs = append(filter.SearchIDs, hit.ID)
+		}
+	}
+	return nil
+}
+
+// indexSongs and unindexSongs only log failures: the songs are committed and
+// a reindex repairs the index.
+func (s *songService) indexSongs(ctx context.Context, songs ...entity.Song) {
+	if len(songs) == 0 {
+		return
+	}
+	if err := s.index.Put(context.WithoutCancel(ctx), songs...); err != nil {
+		logger.Logger.Error().Err(err).Int("songs", len(songs)).Msg("Failed to index songs in service")
+	}
+}
+
+func (s *songService) unindexSongs(ctx context.Context, ids ...int64) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := s.index.Delete(context.WithoutCancel(ctx), ids...); err != nil {
+		logger.Logger.Error().Err(err).Int("songs", len(ids)).Msg("Failed to remove songs from search index in service")
+	}
+}
@@ -2,91 +2,387 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
-	"net/http"
-	"net/url"
+	"fmt"
+	"hash/fnv"
+	"iter"
+	mathrand "math/rand/v2"
+	"strings"
+	"time"
 
 	"github.com/Zorynix/song-library/internal/entity"
 	errs "github.com/Zorynix/song-library/internal/errors"
 	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/lyrics"
+	"github.com/Zorynix/song-library/internal/musicinfo"
 	"github.com/Zorynix/song-library/internal/repo"
 	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/Zorynix/song-library/internal/search"
+	"github.com/Zorynix/song-library/internal/validation"
 )
 
 type songService struct {
-	repos       *repo.Repositories
-	httpClient  *http.Client
-	musicAPIURL string
+	repos   *repo.Repositories
+	index   search.SearchIndex
+	filters songFilters
+	info    musicinfo.MusicInfoProvider
+	options SongServiceOptions
 }
 
-func NewSongService(repos *repo.Repositories, musicAPIURL string) SongService {
-	return &songService{
-		repos:       repos,
-		httpClient:  &http.Client{},
-		musicAPIURL: musicAPIURL,
-	}
+type SongServiceOptions struct {
+	BulkMaxRows    int
+	FuzzyThreshold float64
 }
 
-type SongDetail struct {
-	ReleaseDate string `json:"releaseDate"`
-	Text        string `json:"text"`
-	Link        string `json:"link"`
+func NewSongService(repos *repo.Repositories, index search.SearchIndex, info musicinfo.MusicInfoProvider, options SongServiceOptions) SongService {
+	return &songService{
+		repos:   repos,
+		index:   index,
+		filters: songFilters{index: index, fuzzyThreshold: options.FuzzyThreshold},
+		info:    info,
+		options: options,
+	}
 }
 
-func (s *songService) GetSongs(ctx context.Context, filter entity.SongFilter) ([]entity.Song, error) {
+func (s *songService) GetSongs(ctx context.Context, filter entity.SongFilter) ([]entity.Song, int, error) {
 	logger.Logger.Debug().
 		Str("group", filter.Group).
 		Str("title", filter.Title).
 		Str("text", filter.Text).
+		Str("q", filter.Query).
 		Int("limit", filter.Limit).
 		Int("offset", filter.Offset).
+		Str("filter", filter.Filter).
 		Msg("Fetching songs")
 
+	if err := s.filters.prepare(ctx, &filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return nil, 0, err
+	}
+
 	songs, err := s.repos.Song.GetSongs(ctx, filter)
 	if err != nil {
 		logger.Logger.Error().Err(err).Msg("Failed to fetch songs in service")
-		return nil, errs.ErrInternal
+		return nil, 0, errs.ErrInternal
+	}
+
+	total, err := s.repos.Song.CountSongs(ctx, filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to count songs in service")
+		return nil, 0, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int("count", len(songs)).Int("total", total).Msg("Songs fetched successfully in service")
+	return songs, total, nil
+}
+
+func (s *songService) StreamSongs(ctx context.Context, filter entity.SongFilter) (iter.Seq2[entity.Song, error], error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("q", filter.Query).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Str("filter", filter.Filter).
+		Msg("Streaming songs")
+
+	limit := filter.Limit
+	filter.Limit = min(limit, 0)
+	if err := s.filters.prepare(ctx, &filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return nil, err
+	}
+	filter.Limit = limit
+
+	return func(yield func(entity.Song, error) bool) {
+		for song, err := range s.repos.Song.ScanSongs(ctx, filter) {
+			if err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to stream songs in service")
+				yield(entity.Song{}, errs.ErrInternal)
+				return
+			}
+			if !yield(song, nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+const maxSuggestions = 3
+
+func (s *songService) DidYouMean(ctx context.Context, filter entity.SongFilter) ([]entity.Suggestion, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("q", filter.Query).
+		Msg("Fetching did you mean suggestions")
+
+	// A full-text query may name either a group or a title.
+	lookups := []struct{ param, field, value string }{
+		{"group", "group", filter.Group},
+		{"title", "title", filter.Title},
+		{"q", "title", filter.Query},
+		{"q", "group", filter.Query},
+	}
+
+	suggestions := []entity.Suggestion{}
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+		found, err := s.repos.Song.SuggestSongNames(ctx, lookup.field, lookup.value, s.options.FuzzyThreshold, maxSuggestions)
+		if err != nil {
+			logger.Logger.Error().Err(err).Str("field", lookup.field).Msg("Failed to fetch suggestions in service")
+			return nil, errs.ErrInternal
+		}
+		for _, suggestion := range found {
+			if !strings.EqualFold(suggestion.Value, lookup.value) {
+				suggestion.Field = lookup.param
+				suggestions = append(suggestions, suggestion)
+			}
+		}
 	}
 
-	logger.Logger.Info().Int("count", len(songs)).Msg("Songs fetched successfully in service")
-	return songs, nil
+	logger.Logger.Info().Int("count", len(suggestions)).Msg("Did you mean suggestions fetched successfully in service")
+	return suggestions, nil
 }
 
-func (s *songService) GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]string, error) {
+func (s *songService) RandomSong(ctx context.Context, filter entity.SongFilter) (entity.Song, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Msg("Picking random song")
+
+	if err := s.filters.prepare(ctx, &filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return entity.Song{}, err
+	}
+
+	return s.pickSong(ctx, filter, mathrand.Float64())
+}
+
+func (s *songService) DailySong(ctx context.Context, day time.Time) (entity.Song, error) {
+	date := day.Format(time.DateOnly)
+	logger.Logger.Debug().Str("date", date).Msg("Picking song of the day")
+
+	hash := fnv.New64a()
+	hash.Write([]byte(date))
+	// The top 53 bits fit a float64 exactly, so the position stays below 1.
+	position := float64(binary.BigEndian.Uint64(hash.Sum(nil))>>11) / (1 << 53)
+
+	return s.pickSong(ctx, entity.SongFilter{}, position)
+}
+
+func (s *songService) pickSong(ctx context.Context, filter entity.SongFilter, position float64) (entity.Song, error) {
+	song, err := s.repos.Song.PickSong(ctx, filter, position)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to pick song in service")
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Song{}, errs.ErrNotFound
+		}
+		return entity.Song{}, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int64("id", song.ID).Msg("Song picked successfully in service")
+	return song, nil
+}
+
+func (s *songService) GetSongVerses(ctx context.Context, pagination entity.VersePagination) ([]entity.Verse, int, error) {
 	logger.Logger.Debug().
 		Int64("song_id", pagination.SongID).
 		Int("limit", pagination.Limit).
 		Int("offset", pagination.Offset).
+		Str("unit", string(pagination.Unit)).
 		Msg("Fetching song verses")
 
-	if pagination.SongID <= 0 {
-		logger.Logger.Error().Int64("song_id", pagination.SongID).Msg("Invalid song ID in service")
-		return nil, errs.ErrInvalidInput
+	if err := validation.VersePagination(pagination); err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", pagination.SongID).Msg("Invalid verse pagination in service")
+		return nil, 0, err
 	}
 
-	verses, err := s.repos.Song.GetSongVerses(ctx, pagination)
+	verses, total, err := s.repos.Song.GetSongVerses(ctx, pagination)
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("song_id", pagination.SongID).Msg("Failed to fetch song verses in service")
-		return nil, errs.ErrInternal
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, 0, errs.ErrNotFound
+		}
+		return nil, 0, errs.ErrInternal
 	}
 
 	logger.Logger.Info().
 		Int64("song_id", pagination.SongID).
 		Int("verse_count", len(verses)).
+		Int("total", total).
 		Msg("Song verses fetched successfully in service")
+	return verses, total, nil
+}
+
+func (s *songService) GetSongVerse(ctx context.Context, songID int64, unit entity.VerseUnit, index int) (entity.SongVerse, error) {
+	logger.Logger.Debug().
+		Int64("song_id", songID).
+		Str("unit", string(unit)).
+		Int("index", index).
+		Msg("Fetching song verse")
+
+	if err := validation.Verse(songID, unit, index); err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", songID).Msg("Invalid verse in service")
+		return entity.SongVerse{}, err
+	}
+
+	pagination := entity.VersePagination{SongID: songID, Limit: 1, Offset: index, Unit: unit}
+	verses, total, err := s.repos.Song.GetSongVerses(ctx, pagination)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", songID).Msg("Failed to fetch song verse in service")
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.SongVerse{}, errs.ErrNotFound
+		}
+		return entity.SongVerse{}, errs.ErrInternal
+	}
+	if len(verses) == 0 {
+		logger.Logger.Warn().Int64("song_id", songID).Int("index", index).Int("total", total).Msg("Verse index exceeds verses count")
+		return entity.SongVerse{}, fmt.Errorf("%w: song %d has %d verses", errs.ErrNotFound, songID, total)
+	}
+
+	if unit == "" {
+		unit = entity.VerseUnitStanza
+	}
+
+	logger.Logger.Info().Int64("song_id", songID).Int("index", index).Msg("Song verse fetched successfully in service")
+	return entity.SongVerse{
+		SongID: songID,
+		Unit:   unit,
+		Index:  index,
+		Total:  total,
+		Text:   verses[0].Text,
+	}, nil
+}
+
+func (s *songService) GetVersesBySongIDs(ctx context.Context, ids []int64) (map[int64][]string, error) {
+	logger.Logger.Debug().Ints64("ids", ids).Msg("Fetching verses for songs")
+
+	texts, err := s.repos.Song.GetSongTexts(ctx, ids)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to fetch song texts in service")
+		return nil, errs.ErrInternal
+	}
+
+	verses := make(map[int64][]string, len(texts))
+	for id, text := range texts {
+		verses[id] = lyrics.Verses(text)
+	}
+
+	logger.Logger.Info().Int("count", len(verses)).Msg("Verses for songs fetched successfully in service")
 	return verses, nil
 }
 
+const snippetRadius = 40
+
+func (s *songService) SearchLyrics(ctx context.Context, search entity.LyricSearch) ([]entity.LyricMatch, int, error) {
+	logger.Logger.Debug().
+		Str("query", search.Query).
+		Int("limit", search.Limit).
+		Int("offset", search.Offset).
+		Msg("Searching lyrics")
+
+	if err := validation.LyricSearch(search); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid lyric search in service")
+		return nil, 0, err
+	}
+
+	found, total, err := s.repos.Song.SearchLyrics(ctx, search)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to search lyrics in service")
+		return nil, 0, errs.ErrInternal
+	}
+
+	// A verse Go cannot mark the query in still counts as a hit.
+	matches := make([]entity.LyricMatch, 0, len(found))
+	for _, song := range found {
+		match := entity.LyricMatch{SongID: song.SongID, Group: song.Group, Title: song.Title, Hits: make([]entity.VerseHit, 0, len(song.Verses))}
+		for _, verse := range song.Verses {
+			snippet := lyrics.Highlight(verse.Text, search.Query, snippetRadius)
+			match.Hits = append(match.Hits, entity.VerseHit{VerseIndex: verse.Index, Snippet: snippet})
+		}
+		matches = append(matches, match)
+	}
+
+	logger.Logger.Info().Int("count", len(matches)).Int("total", total).Msg("Lyrics searched successfully in service")
+	return matches, total, nil
+}
+
+const defaultCompletions = 10
+
+func (s *songService) CompleteSongNames(ctx context.Context, completion entity.NameCompletion) ([]entity.Completion, error) {
+	logger.Logger.Debug().
+		Str("field", completion.Field).
+		Str("prefix", completion.Prefix).
+		Int("limit", completion.Limit).
+		Msg("Completing song names")
+
+	if err := validation.NameCompletion(completion); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid name completion in service")
+		return nil, err
+	}
+	if completion.Limit == 0 {
+		completion.Limit = defaultCompletions
+	}
+
+	completions, err := s.repos.Song.CompleteSongNames(ctx, completion)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("field", completion.Field).Msg("Failed to complete song names in service")
+		return nil, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int("count", len(completions)).Str("field", completion.Field).Msg("Song names completed successfully in service")
+	return completions, nil
+}
+
+const defaultSimilarSongs = 10
+
+func (s *songService) SimilarSongs(ctx context.Context, query entity.SimilarQuery) ([]entity.SimilarSong, error) {
+	logger.Logger.Debug().
+		Int64("song_id", query.SongID).
+		Int("limit", query.Limit).
+		Bool("boost_group", query.BoostGroup).
+		Bool("boost_tags", query.BoostTags).
+		Msg("Fetching similar songs")
+
+	if err := validation.SimilarQuery(query); err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", query.SongID).Msg("Invalid similar songs query in service")
+		return nil, err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSimilarSongs
+	}
+
+	similar, err := s.repos.Song.SimilarSongs(ctx, query)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("song_id", query.SongID).Msg("Failed to fetch similar songs in service")
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int64("song_id", query.SongID).Int("count", len(similar)).Msg("Similar songs fetched successfully in service")
+	return similar, nil
+}
+
 func (s *songService) DeleteSong(ctx context.Context, id int64) error {
 	logger.Logger.Debug().Int64("id", id).Msg("Deleting song")
 
-	if id <= 0 {
-		logger.Logger.Error().Int64("id", id).Msg("Invalid song ID in service")
-		return errs.ErrInvalidInput
+	if err := validation.SongID(id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Invalid song ID in service")
+		return err
 	}
 
-	err := s.repos.Song.DeleteSong(ctx, id)
+	_, err := s.repos.Song.DeleteSong(ctx, id, songEvent(entity.SongDeleted))
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to delete song in service")
 		if errors.Is(err, repoerrs.ErrNotFound) {
@@ -96,32 +392,38 @@ func (s *songService) DeleteSong(ctx context.Context, id int64) error {
 	}
 
 	logger.Logger.Info().Int64("id", id).Msg("Song deleted successfully in service")
+	s.unindexSongs(ctx, id)
 	return nil
 }
 
-func (s *songService) UpdateSong(ctx context.Context, song entity.Song) error {
+func (s *songService) UpdateSong(ctx context.Context, song entity.Song) (entity.Song, error) {
 	logger.Logger.Debug().
 		Int64("id", song.ID).
 		Str("group", song.Group).
 		Str("title", song.Title).
 		Msg("Updating song")
 
-	if song.ID <= 0 {
-		logger.Logger.Error().Int64("id", song.ID).Msg("Invalid song ID in service")
-		return errs.ErrInvalidInput
+	if err := validation.Song(song); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", song.ID).Msg("Invalid song in service")
+		return entity.Song{}, err
 	}
 
-	err := s.repos.Song.UpdateSong(ctx, song)
+	if song.Language == "" {
+		song.Language = lyrics.DetectLanguage(song.Text)
+	}
+
+	updated, err := s.repos.Song.UpdateSong(ctx, song, songEvent(entity.SongUpdated))
 	if err != nil {
 		logger.Logger.Error().Err(err).Int64("id", song.ID).Msg("Failed to update song in service")
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return errs.ErrNotFound
+			return entity.Song{}, errs.ErrNotFound
 		}
-		return errs.ErrInternal
+		return entity.Song{}, errs.ErrInternal
 	}
 
 	logger.Logger.Info().Int64("id", song.ID).Msg("Song updated successfully in service")
-	return nil
+	s.indexSongs(ctx, updated)
+	return updated, nil
 }
 
 func (s *songService) AddSong(ctx context.Context, song entity.Song) (entity.Song, error) {
@@ -130,48 +432,53 @@ func (s *songService) AddSong(ctx context.Context, song entity.Song) (entity.Son
 		Str("title", song.Title).
 		Msg("Adding new song")
 
-	params := url.Values{}
-	params.Add("group", song.Group)
-	params.Add("song", song.Title)
-	reqURL := s.musicAPIURL + "?" + params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		logger.Logger.Error().Err(err).Str("url", reqURL).Msg("Failed to create request to music API")
-		return entity.Song{}, errs.ErrInternal
+	if err := validation.NewSong(song); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song in service")
+		return entity.Song{}, err
 	}
 
-	resp, err := s.httpClient.Do(req)
+	songDetail, err := s.info.SongInfo(ctx, song.Group, song.Title)
 	if err != nil {
-		logger.Logger.Error().Err(err).Str("url", reqURL).Msg("Failed to fetch data from music API")
-		return entity.Song{}, errs.ErrInternal
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Logger.Error().
-			Int("status", resp.StatusCode).
-			Str("url", reqURL).
-			Msg("Music API returned non-200 status")
-		return entity.Song{}, errs.ErrInternal
-	}
-
-	var songDetail SongDetail
-	if err := json.NewDecoder(resp.Body).Decode(&songDetail); err != nil {
-		logger.Logger.Error().Err(err).Msg("Failed to decode music API response")
-		return entity.Song{}, errs.ErrInternal
+		logger.Logger.Error().Err(err).Str("group", song.Group).Str("title", song.Title).Msg("Failed to get song info in service")
+		switch {
+		case errors.Is(err, musicinfo.ErrNotFound):
+			return entity.Song{}, errs.ErrSongInfoNotFound
+		case errors.Is(err, musicinfo.ErrBadRequest):
+			return entity.Song{}, errs.ErrInvalidInput
+		case errors.Is(err, musicinfo.ErrUnavailable):
+			return entity.Song{}, errs.ErrMusicAPIFailed
+		default:
+			return entity.Song{}, errs.ErrInternal
+		}
 	}
 
 	song.ReleaseDate = songDetail.ReleaseDate
 	song.Text = songDetail.Text
 	song.Link = songDetail.Link
+	song.Language = lyrics.DetectLanguage(song.Text)
 
-	createdSong, err := s.repos.Song.AddSong(ctx, song)
+	createdSong, err := s.repos.Song.AddSong(ctx, song, songEvent(entity.SongCreated))
 	if err != nil {
 		logger.Logger.Error().Err(err).Str("group", song.Group).Str("title", song.Title).Msg("Failed to add song in service")
 		return entity.Song{}, errs.ErrInternal
 	}
 
 	logger.Logger.Info().Int64("id", createdSong.ID).Msg("Song added successfully in service")
+	s.indexSongs(ctx, createdSong)
 	return createdSong, nil
 }
+
+func songEvent(eventType entity.SongEventType) entity.SongEventFunc {
+	return func(song entity.Song) entity.SongEvent {
+		id := make([]byte, 16)
+		rand.Read(id)
+
+		return entity.SongEvent{
+			ID:         hex.EncodeToString(id),
+			Type:       eventType,
+			OccurredAt: time.Now().UTC(),
+			SongID:     song.ID,
+			Song:       &song,
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	"github.com/Zorynix/song-library/internal/musicinfo"
+	"github.com/Zorynix/song-library/internal/repo"
+	"github.com/Zorynix/song-library/internal/search"
+)
+
+// songRepoStub stores added songs in memory; the methods AddSong does not
+// use are left to the embedded nil interface.
+type songRepoStub struct {
+	repo.SongRepo
+	added  []entity.Song
+	events []entity.SongEvent
+}
+
+func (r *songRepoStub) AddSong(ctx context.Context, song entity.Song, newEvent entity.SongEventFunc) (entity.Song, error) {
+	song.ID = int64(len(r.added) + 1)
+	r.added = append(r.added, song)
+	r.events = append(r.events, newEvent(song))
+	return song, nil
+}
+
+var muse = musicinfo.FakeSong{
+	Group: "Muse",
+	Song:  "Supermassive Black Hole",
+	SongDetail: entity.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	},
+}
+
+func newTestSongService(info musicinfo.MusicInfoProvider) (SongService, *songRepoStub) {
+	songs := &songRepoStub{}
+	service := NewSongService(&repo.Repositories{Song: songs}, search.NewPostgresIndex(nil), info, SongServiceOptions{})
+	return service, songs
+}
+
+func TestAddSong(t *testing.T) {
+	service, songs := newTestSongService(musicinfo.NewFake(muse))
+
+	song, err := service.AddSong(context.Background(), entity.Song{Group: muse.Group, Title: muse.Song})
+	if err != nil {
+		t.Fatalf("AddSong() error = %v", err)
+	}
+	if song.ID != 1 || song.ReleaseDate != muse.ReleaseDate || song.Text != muse.Text || song.Link != muse.Link {
+		t.Errorf("AddSong() = %+v, want id 1 with the details of the music API", song)
+	}
+	if len(songs.added) != 1 {
+		t.Fatalf("repo got %d songs, want 1", len(songs.added))
+	}
+	if len(songs.events) != 1 || songs.events[0].Type != entity.SongCreated || songs.events[0].SongID != song.ID {
+		t.Errorf("recorded %+v, want one %s event of song %d", songs.events, entity.SongCreated, song.ID)
+	}
+}
+
+func TestAddSongErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		song    entity.Song
+		failure error
+		want    error
+	}{
+		{name: "invalid song", song: entity.Song{Title: muse.Song}, want: errs.ErrInvalidInput},
+		{name: "not found", song: entity.Song{Group: muse.Group, Title: "Uprising"}, want: errs.ErrSongInfoNotFound},
+		{name: "rejected", song: entity.Song{Group: muse.Group, Title: muse.Song}, failure: musicinfo.ErrBadRequest, want: errs.ErrInvalidInput},
+		{name: "unavailable", song: entity.Song{Group: muse.Group, Title: muse.Song}, failure: fmt.Errorf("%w: status 503", musicinfo.ErrUnavailable), want: errs.ErrMusicAPIFailed},
+		{name: "unexpected", song: entity.Song{Group: muse.Group, Title: muse.Song}, failure: errors.New("boom"), want: errs.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := musicinfo.NewFake(muse)
+			info.Fail(tt.failure)
+			service, songs := newTestSongService(info)
+
+			_, err := service.AddSong(context.Background(), tt.song)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("AddSong() error = %v, want %v", err, tt.want)
+			}
+			if len(songs.added) != 0 || len(songs.events) != 0 {
+				t.Errorf("failed AddSong() stored %d songs and recorded %d events", len(songs.added), len(songs.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+)
+
+// SongTermRefresher recounts the lyric words similar songs are weighted by.
+type SongTermRefresher struct {
+	repos    *repo.Repositories
+	interval time.Duration
+}
+
+func NewSongTermRefresher(repos *repo.Repositories, interval time.Duration) *SongTermRefresher {
+	return &SongTermRefresher{repos: repos, interval: interval}
+}
+
+func (t *SongTermRefresher) Run(ctx context.Context) {
+	logger.Logger.Info().Dur("interval", t.interval).Msg("Starting song term refresher")
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Logger.Info().Msg("Song term refresher stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if err := t.repos.Song.RefreshSongTerms(ctx); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to refresh song term counts")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+	"github.com/Zorynix/song-library/internal/search"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+type statsService struct {
+	repos   *repo.Repositories
+	filters songFilters
+}
+
+type StatsServiceOptions struct {
+	FuzzyThreshold float64
+}
+
+func NewStatsService(repos *repo.Repositories, index search.SearchIndex, options StatsServiceOptions) StatsService {
+	return &statsService{
+		repos:   repos,
+		filters: songFilters{index: index, fuzzyThreshold: options.FuzzyThreshold},
+	}
+}
+
+func (s *statsService) GetSongStats(ctx context.Context, filter entity.SongFilter) (entity.SongStats, error) {
+	logger.Logger.Debug().
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Msg("Fetching song stats")
+
+	if err := s.filters.prepare(ctx, &filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return entity.SongStats{}, err
+	}
+
+	stats, err := s.repos.Stats.GetSongStats(ctx, filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to fetch song stats in service")
+		return entity.SongStats{}, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int("songs", stats.Songs).Msg("Song stats fetched successfully in service")
+	return stats, nil
+}
+
+func (s *statsService) CountSongsBy(ctx context.Context, filter entity.SongFilter, dimension entity.StatsDimension) ([]entity.StatBucket, int, error) {
+	logger.Logger.Debug().
+		Str("dimension", string(dimension)).
+		Str("group", filter.Group).
+		Str("title", filter.Title).
+		Str("text", filter.Text).
+		Str("filter", filter.Filter).
+		Int("limit", filter.Limit).
+		Int("offset", filter.Offset).
+		Msg("Counting songs by dimension")
+
+	if err := s.filters.prepare(ctx, &filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return nil, 0, err
+	}
+
+	buckets, total, err := s.repos.Stats.CountSongsBy(ctx, filter, dimension)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("dimension", string(dimension)).Msg("Failed to count songs by dimension in service")
+		return nil, 0, errs.ErrInternal
+	}
+
+	logger.Logger.Info().
+		Str("dimension", string(dimension)).
+		Int("count", len(buckets)).
+		Int("total", total).
+		Msg("Songs counted by dimension successfully in service")
+	return buckets, total, nil
+}
+
+const defaultFacetBuckets = 10
+
+func (s *statsService) SongFacets(ctx context.Context, query entity.FacetQuery) ([]entity.Facet, error) {
+	logger.Logger.Debug().
+		Str("group", query.Filter.Group).
+		Str("title", query.Filter.Title).
+		Str("text", query.Filter.Text).
+		Str("filter", query.Filter.Filter).
+		Int("dimensions", len(query.Dimensions)).
+		Int("limit", query.Limit).
+		Msg("Counting song facets")
+
+	if err := validation.FacetQuery(query); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid facet query in service")
+		return nil, err
+	}
+	if err := s.filters.prepare(ctx, &query.Filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid song filter in service")
+		return nil, err
+	}
+	dimensions := entity.StatsDimensions
+	if len(query.Dimensions) > 0 {
+		dimensions = nil
+		for _, dimension := range query.Dimensions {
+			if !slices.Contains(dimensions, dimension) {
+				dimensions = append(dimensions, dimension)
+			}
+		}
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultFacetBuckets
+	}
+
+	facets, err := s.repos.Stats.CountFacets(ctx, query.Filter, dimensions, query.Limit)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to count song facets in service")
+		return nil, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int("facets", len(facets)).Msg("Song facets counted successfully in service")
+	return facets, nil
+}
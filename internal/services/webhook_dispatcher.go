@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Zorynix/song-library/internal/backoff"
+	"github.com/Zorynix/song-library/internal/entity"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+const (
+	WebhookEventHeader     = "X-Song-Library-Event"
+	WebhookDeliveryHeader  = "X-Song-Library-Delivery"
+	WebhookSignatureHeader = "X-Song-Library-Signature"
+
+	maxWebhookErrorLength = 1000
+)
+
+type WebhookDispatcherOptions struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	BatchSize    int
+	// MinBackoff is doubled after every failed attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// WebhookDispatcher sends queued webhook deliveries; each is claimed by one
+// instance.
+type WebhookDispatcher struct {
+	repos      *repo.Repositories
+	httpClient *http.Client
+	options    WebhookDispatcherOptions
+}
+
+func NewWebhookDispatcher(repos *repo.Repositories, options WebhookDispatcherOptions) *WebhookDispatcher {
+	// Public addresses are checked after the name is resolved.
+	dialer := &net.Dialer{Timeout: options.Timeout, Control: dialPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookDispatcher{
+		repos: repos,
+		httpClient: &http.Client{
+			Timeout:   options.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		options: options,
+	}
+}
+
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !validation.PublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("webhook target %s is not a public address", addrPort.Addr())
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	logger.Logger.Info().Dur("poll_interval", d.options.PollInterval).Msg("Starting webhook dispatcher")
+
+	ticker := time.NewTicker(d.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if d.dispatch(ctx) == d.options.BatchSize {
+			// A full batch means more deliveries may already be due.
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Logger.Info().Msg("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) int {
+	// The lease outlives one request timeout so that a delivery in flight is
+	// not sent twice; a late result fails the claim check.
+	jobs, err := d.repos.Webhook.ClaimDeliveries(ctx, d.options.BatchSize, 2*d.options.Timeout)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to claim webhook deliveries")
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, job)
+		}()
+	}
+	wg.Wait()
+	return len(jobs)
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, job entity.DeliveryJob) {
+	statusCode, err := d.send(ctx, job)
+	if err == nil {
+		logger.Logger.Info().Int64("id", job.ID).Int64("webhook_id", job.WebhookID).Int("status", statusCode).Msg("Webhook delivered")
+		if err := d.repos.Webhook.MarkDelivered(ctx, job, statusCode); err != nil && !errors.Is(err, repoerrs.ErrDeliveryLeaseLost) {
+			logger.Logger.Error().Err(err).Int64("id", job.ID).Msg("Failed to mark webhook delivery as delivered")
+		}
+		return
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	lastError := err.Error()
+	if len(lastError) > maxWebhookErrorLength {
+		lastError = lastError[:maxWebhookErrorLength]
+	}
+
+	var nextAttemptAt *time.Time
+	if job.Attempts < d.options.MaxAttempts {
+		next := time.Now().Add(backoff.Exponential(job.Attempts, d.options.MinBackoff, d.options.MaxBackoff))
+		nextAttemptAt = &next
+	}
+
+	logger.Logger.Warn().
+		Err(err).
+		Int64("id", job.ID).
+		Int64("webhook_id", job.WebhookID).
+		Int("attempt", job.Attempts).
+		Bool("dead", nextAttemptAt == nil).
+		Msg("Webhook delivery failed")
+
+	if err := d.repos.Webhook.MarkFailed(ctx, job, code, lastError, nextAttemptAt); err != nil && !errors.Is(err, repoerrs.ErrDeliveryLeaseLost) {
+		logger.Logger.Error().Err(err).Int64("id", job.ID).Msg("Failed to record webhook delivery failure")
+	}
+}
+
+// send treats any 2xx response as delivered and a redirect as a failure.
+func (d *WebhookDispatcher) send(ctx context.Context, job entity.DeliveryJob) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "song-library-webhooks")
+	req.Header.Set(WebhookEventHeader, string(job.Event))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(job.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, "t="+timestamp+",v1="+SignWebhook(job.Secret, timestamp, job.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "timestamp.payload" under secret.
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+	logger "github.com/Zorynix/song-library/internal/logger"
+	"github.com/Zorynix/song-library/internal/repo"
+	repoerrs "github.com/Zorynix/song-library/internal/repo/repo_errors"
+	"github.com/Zorynix/song-library/internal/validation"
+)
+
+type webhookService struct {
+	repos *repo.Repositories
+}
+
+func NewWebhookService(repos *repo.Repositories) WebhookService {
+	return &webhookService{repos: repos}
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	logger.Logger.Debug().Msg("Fetching webhooks")
+
+	webhooks, err := s.repos.Webhook.GetWebhooks(ctx)
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to fetch webhooks in service")
+		return nil, errs.ErrInternal
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	logger.Logger.Info().Int("count", len(webhooks)).Msg("Webhooks fetched successfully in service")
+	return webhooks, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id int64) (entity.Webhook, error) {
+	logger.Logger.Debug().Int64("id", id).Msg("Fetching webhook")
+
+	if err := validation.WebhookID(id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Invalid webhook ID in service")
+		return entity.Webhook{}, err
+	}
+
+	webhook, err := s.repos.Webhook.GetWebhook(ctx, id)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to fetch webhook in service")
+		return entity.Webhook{}, webhookError(err)
+	}
+	webhook.Secret = ""
+
+	logger.Logger.Info().Int64("id", id).Msg("Webhook fetched successfully in service")
+	return webhook, nil
+}
+
+// AddWebhook returns the secret once; it is generated when left empty.
+func (s *webhookService) AddWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	logger.Logger.Debug().Str("url", webhook.URL).Strs("events", webhook.Events).Msg("Adding webhook")
+
+	if err := validation.NewWebhook(webhook); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid webhook in service")
+		return entity.Webhook{}, err
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to generate webhook secret")
+			return entity.Webhook{}, errs.ErrInternal
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	created, err := s.repos.Webhook.AddWebhook(ctx, webhook)
+	if err != nil {
+		logger.Logger.Error().Err(err).Str("url", webhook.URL).Msg("Failed to add webhook in service")
+		return entity.Webhook{}, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int64("id", created.ID).Msg("Webhook added successfully in service")
+	return created, nil
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	logger.Logger.Debug().Int64("id", webhook.ID).Str("url", webhook.URL).Msg("Updating webhook")
+
+	if err := validation.Webhook(webhook); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", webhook.ID).Msg("Invalid webhook in service")
+		return entity.Webhook{}, err
+	}
+
+	updated, err := s.repos.Webhook.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", webhook.ID).Msg("Failed to update webhook in service")
+		return entity.Webhook{}, webhookError(err)
+	}
+	updated.Secret = ""
+
+	logger.Logger.Info().Int64("id", webhook.ID).Msg("Webhook updated successfully in service")
+	return updated, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	logger.Logger.Debug().Int64("id", id).Msg("Deleting webhook")
+
+	if err := validation.WebhookID(id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Invalid webhook ID in service")
+		return err
+	}
+
+	if err := s.repos.Webhook.DeleteWebhook(ctx, id); err != nil {
+		logger.Logger.Error().Err(err).Int64("id", id).Msg("Failed to delete webhook in service")
+		return webhookError(err)
+	}
+
+	logger.Logger.Info().Int64("id", id).Msg("Webhook deleted successfully in service")
+	return nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, filter entity.DeliveryFilter) ([]entity.WebhookDelivery, int, error) {
+	logger.Logger.Debug().
+		Int64("webhook_id", filter.WebhookID).
+		Str("status", string(filter.Status)).
+		Msg("Fetching webhook deliveries")
+
+	if err := validation.DeliveryFilter(filter); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid delivery filter in service")
+		return nil, 0, err
+	}
+
+	if _, err := s.repos.Webhook.GetWebhook(ctx, filter.WebhookID); err != nil {
+		logger.Logger.Error().Err(err).Int64("webhook_id", filter.WebhookID).Msg("Failed to fetch webhook in service")
+		return nil, 0, webhookError(err)
+	}
+
+	deliveries, total, err := s.repos.Webhook.GetDeliveries(ctx, filter)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("webhook_id", filter.WebhookID).Msg("Failed to fetch webhook deliveries in service")
+		return nil, 0, errs.ErrInternal
+	}
+
+	logger.Logger.Info().Int("count", len(deliveries)).Int("total", total).Msg("Webhook deliveries fetched successfully in service")
+	return deliveries, total, nil
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, webhookID, deliveryID int64) (entity.WebhookDelivery, error) {
+	logger.Logger.Debug().Int64("webhook_id", webhookID).Int64("id", deliveryID).Msg("Redelivering webhook delivery")
+
+	var v validation.Violations
+	validation.ID(&v, "id", webhookID)
+	validation.ID(&v, "deliveryId", deliveryID)
+	if err := v.Err(); err != nil {
+		logger.Logger.Error().Err(err).Msg("Invalid redelivery in service")
+		return entity.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.repos.Webhook.Redeliver(ctx, webhookID, deliveryID)
+	if err != nil {
+		logger.Logger.Error().Err(err).Int64("id", deliveryID).Msg("Failed to redeliver webhook delivery in service")
+		return entity.WebhookDelivery{}, webhookError(err)
+	}
+
+	logger.Logger.Info().Int64("id", deliveryID).Msg("Webhook delivery queued again in service")
+	return delivery, nil
+}
+
+func webhookError(err error) error {
+	if errors.Is(err, repoerrs.ErrWebhookNotFound) || errors.Is(err, repoerrs.ErrDeliveryNotFound) {
+		return errs.ErrNotFound
+	}
+	return errs.ErrInternal
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+)
+
+func BulkDelete(bulk entity.BulkDelete) error {
+	var v Violations
+	songSelector(&v, bulk.SongSelector)
+	return v.Err()
+}
+
+func BulkUpdate(bulk entity.BulkUpdate) error {
+	var v Violations
+	songSelector(&v, bulk.SongSelector)
+	songPatch(&v, bulk.Set)
+	return v.Err()
+}
+
+func songSelector(v *Violations, selector entity.SongSelector) {
+	if (len(selector.IDs) == 0) == (selector.Filter == nil) {
+		v.Add("ids", "exactly one of ids and filter is required")
+		return
+	}
+
+	for i, id := range selector.IDs {
+		ID(v, fmt.Sprintf("ids[%d]", i), id)
+	}
+
+	if filter := selector.Filter; filter != nil {
+		if filter.Group == "" && filter.Title == "" && filter.Text == "" && filter.Query == "" && filter.Language == "" && filter.Filter == "" {
+			v.Add("filter", "must set at least one condition")
+		}
+		var validationErr *errs.ValidationError
+		if errors.As(SongFilter(*filter), &validationErr) {
+			for _, field := range validationErr.Fields {
+				v.Add("filter."+field.Field, field.Message)
+			}
+		}
+	}
+}
+
+func songPatch(v *Violations, patch entity.SongPatch) {
+	if patch == (entity.SongPatch{}) {
+		v.Add("set", "must set at least one field")
+		return
+	}
+
+	if patch.Group != nil {
+		if strings.TrimSpace(*patch.Group) == "" {
+			v.Add("set.group", "must not be blank")
+		}
+		v.maxLength("set.group", *patch.Group, MaxGroupLength)
+	}
+	if patch.Title != nil {
+		if strings.TrimSpace(*patch.Title) == "" {
+			v.Add("set.title", "must not be blank")
+		}
+		v.maxLength("set.title", *patch.Title, MaxTitleLength)
+	}
+	if patch.ReleaseDate != nil {
+		v.maxLength("set.releaseDate", *patch.ReleaseDate, MaxReleaseDateLength)
+	}
+	if patch.Link != nil && *patch.Link != "" {
+		v.maxLength("set.link", *patch.Link, MaxLinkLength)
+		if u, err := url.ParseRequestURI(*patch.Link); err != nil || u.Host == "" {
+			v.Add("set.link", "must be an absolute URL")
+		}
+	}
+	if patch.Tags != nil {
+		var tags Violations
+		songTags(&tags, *patch.Tags)
+		for _, field := range tags.fields {
+			v.Add("set."+field.Field, field.Message)
+		}
+	}
+}
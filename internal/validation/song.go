@@ -0,0 +1,208 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	"github.com/Zorynix/song-library/internal/filterql"
+	"github.com/Zorynix/song-library/internal/lyrics"
+)
+
+const (
+	MaxGroupLength       = 255
+	MaxTitleLength       = 255
+	MaxReleaseDateLength = 50
+	MaxLinkLength        = 255
+	MaxFilterLength      = 255
+	MaxLimit             = 100
+	MaxFilterExprLength  = 1000
+	MaxTags              = 20
+	MaxTagLength         = 50
+	MaxCompletions       = 20
+	MinCompletionPrefix  = 2
+)
+
+func NewSong(song entity.Song) error {
+	var v Violations
+	songNames(&v, song)
+	songTags(&v, song.Tags)
+	return v.Err()
+}
+
+func Song(song entity.Song) error {
+	var v Violations
+	ID(&v, "id", song.ID)
+	songNames(&v, song)
+	v.maxLength("releaseDate", song.ReleaseDate, MaxReleaseDateLength)
+	v.maxLength("link", song.Link, MaxLinkLength)
+	if song.Link != "" {
+		if u, err := url.ParseRequestURI(song.Link); err != nil || u.Host == "" {
+			v.Add("link", "must be an absolute URL")
+		}
+	}
+	songTags(&v, song.Tags)
+	songLanguage(&v, "language", song.Language)
+	return v.Err()
+}
+
+func SongFilter(filter entity.SongFilter) error {
+	var v Violations
+	v.maxLength("group", filter.Group, MaxFilterLength)
+	v.maxLength("title", filter.Title, MaxFilterLength)
+	v.maxLength("text", filter.Text, MaxFilterLength)
+	v.maxLength("q", filter.Query, MaxFilterLength)
+	songLanguage(&v, "lang", filter.Language)
+	pagination(&v, filter.Limit, filter.Offset)
+	if filter.Fields != nil && len(filter.Fields) == 0 {
+		v.Add("fields", "must name at least one field")
+	}
+	for _, field := range filter.Fields {
+		if !slices.Contains(entity.SongFields, field) {
+			v.Add("fields", fmt.Sprintf("unknown field %q", field))
+		}
+	}
+	v.maxLength("filter", filter.Filter, MaxFilterExprLength)
+	if filter.Filter != "" {
+		if _, err := filterql.Parse(filter.Filter); err != nil {
+			v.Add("filter", err.Error())
+		}
+	}
+	return v.Err()
+}
+
+func VersePagination(p entity.VersePagination) error {
+	var v Violations
+	ID(&v, "id", p.SongID)
+	pagination(&v, p.Limit, p.Offset)
+	verseUnit(&v, p.Unit)
+	return v.Err()
+}
+
+func Verse(songID int64, unit entity.VerseUnit, index int) error {
+	var v Violations
+	ID(&v, "id", songID)
+	verseUnit(&v, unit)
+	if index < 0 {
+		v.Add("n", "must not be negative")
+	}
+	return v.Err()
+}
+
+func LyricSearch(search entity.LyricSearch) error {
+	var v Violations
+	if strings.TrimSpace(search.Query) == "" {
+		v.Add("q", "is required")
+	}
+	v.maxLength("q", search.Query, MaxFilterLength)
+	pagination(&v, search.Limit, search.Offset)
+	return v.Err()
+}
+
+func NameCompletion(completion entity.NameCompletion) error {
+	var v Violations
+	switch completion.Field {
+	case "group", "title":
+	case "":
+		v.Add("field", "is required")
+	default:
+		v.Add("field", `must be "group" or "title"`)
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(completion.Prefix)) < MinCompletionPrefix {
+		v.Add("prefix", fmt.Sprintf("must be at least %d characters", MinCompletionPrefix))
+	}
+	v.maxLength("prefix", completion.Prefix, MaxFilterLength)
+	if completion.Limit < 0 {
+		v.Add("limit", "must not be negative")
+	}
+	if completion.Limit > MaxCompletions {
+		v.Add("limit", fmt.Sprintf("must be at most %d", MaxCompletions))
+	}
+	return v.Err()
+}
+
+func FacetQuery(query entity.FacetQuery) error {
+	var v Violations
+	v.Merge(SongFilter(query.Filter))
+	for _, dimension := range query.Dimensions {
+		if !slices.Contains(entity.StatsDimensions, dimension) {
+			v.Add("facets", fmt.Sprintf("unknown facet %q", dimension))
+		}
+	}
+	pagination(&v, query.Limit, 0)
+	return v.Err()
+}
+
+func SimilarQuery(query entity.SimilarQuery) error {
+	var v Violations
+	ID(&v, "id", query.SongID)
+	pagination(&v, query.Limit, 0)
+	return v.Err()
+}
+
+func SongID(id int64) error {
+	var v Violations
+	ID(&v, "id", id)
+	return v.Err()
+}
+
+func ID(v *Violations, field string, id int64) {
+	if id <= 0 {
+		v.Add(field, "must be a positive integer")
+	}
+}
+
+func songNames(v *Violations, song entity.Song) {
+	if strings.TrimSpace(song.Group) == "" {
+		v.Add("group", "is required")
+	}
+	if strings.TrimSpace(song.Title) == "" {
+		v.Add("title", "is required")
+	}
+	v.maxLength("group", song.Group, MaxGroupLength)
+	v.maxLength("title", song.Title, MaxTitleLength)
+}
+
+func songTags(v *Violations, tags []string) {
+	if len(tags) > MaxTags {
+		v.Add("tags", fmt.Sprintf("must have at most %d items", MaxTags))
+	}
+	for i, tag := range tags {
+		field := fmt.Sprintf("tags[%d]", i)
+		if strings.TrimSpace(tag) == "" {
+			v.Add(field, "must not be blank")
+		}
+		v.maxLength(field, tag, MaxTagLength)
+	}
+}
+
+func songLanguage(v *Violations, field, language string) {
+	switch language {
+	case "", lyrics.LanguageRussian, lyrics.LanguageEnglish:
+	default:
+		v.Add(field, fmt.Sprintf("must be %q or %q", lyrics.LanguageRussian, lyrics.LanguageEnglish))
+	}
+}
+
+func verseUnit(v *Violations, unit entity.VerseUnit) {
+	switch unit {
+	case "", entity.VerseUnitStanza, entity.VerseUnitLine:
+	default:
+		v.Add("unit", fmt.Sprintf("must be %q or %q", entity.VerseUnitStanza, entity.VerseUnitLine))
+	}
+}
+
+func pagination(v *Violations, limit, offset int) {
+	if limit < 0 {
+		v.Add("limit", "must not be negative")
+	}
+	if limit > MaxLimit {
+		v.Add("limit", fmt.Sprintf("must be at most %d", MaxLimit))
+	}
+	if offset < 0 {
+		v.Add("offset", "must not be negative")
+	}
+}
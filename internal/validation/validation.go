@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	errs "github.com/Zorynix/song-library/internal/errors"
+)
+
+// Violations collects every field error of a request.
+type Violations struct {
+	fields []errs.FieldError
+}
+
+func (v *Violations) Add(field, message string) {
+	v.fields = append(v.fields, errs.FieldError{Field: field, Message: message})
+}
+
+func (v *Violations) Merge(err error) {
+	var validationErr *errs.ValidationError
+	if !errors.As(err, &validationErr) {
+		return
+	}
+	for _, field := range validationErr.Fields {
+		if !slices.Contains(v.fields, field) {
+			v.fields = append(v.fields, field)
+		}
+	}
+}
+
+func (v *Violations) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &errs.ValidationError{Fields: v.fields}
+}
+
+func (v *Violations) maxLength(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.Add(field, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
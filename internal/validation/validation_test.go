@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Zorynix/song-library/internal/entity"
+	errs "github.com/Zorynix/song-library/internal/errors"
+)
+
+func TestViolationsErr(t *testing.T) {
+	var v Violations
+	if err := v.Err(); err != nil {
+		t.Fatalf("Err() of no violations = %v, want nil", err)
+	}
+
+	v.Add("group", "is required")
+	v.Add("title", "is required")
+	err := v.Err()
+	if !errors.Is(err, errs.ErrInvalidInput) {
+		t.Errorf("Err() = %v, want it to match %v", err, errs.ErrInvalidInput)
+	}
+	var validationErr *errs.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Err() = %T, want *errs.ValidationError", err)
+	}
+	want := []errs.FieldError{{Field: "group", Message: "is required"}, {Field: "title", Message: "is required"}}
+	if !slices.Equal(validationErr.Fields, want) {
+		t.Errorf("Err() fields = %v, want %v", validationErr.Fields, want)
+	}
+}
+
+func TestViolationsMerge(t *testing.T) {
+	var other Violations
+	other.Add("group", "is required")
+	other.Add("limit", "must be at most 100")
+
+	var v Violations
+	v.Add("group", "is required")
+	v.Merge(other.Err())
+	v.Merge(errors.New("not a validation error"))
+	v.Merge(nil)
+
+	var validationErr *errs.ValidationError
+	if !errors.As(v.Err(), &validationErr) {
+		t.Fatalf("Err() = %v, want *errs.ValidationError", v.Err())
+	}
+	want := []errs.FieldError{{Field: "group", Message: "is required"}, {Field: "limit", Message: "must be at most 100"}}
+	if !slices.Equal(validationErr.Fields, want) {
+		t.Errorf("merged fields = %v, want %v", validationErr.Fields, want)
+	}
+}
+
+func TestNewSongReportsEveryField(t *testing.T) {
+	err := NewSong(entity.Song{Title: strings.Repeat("a", MaxTitleLength+1), Tags: []string{""}})
+
+	var validationErr *errs.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("NewSong() error = %v, want *errs.ValidationError", err)
+	}
+	want := []errs.FieldError{
+		{Field: "group", Message: "is required"},
+		{Field: "title", Message: "must be at most 255 characters"},
+		{Field: "tags[0]", Message: "must not be blank"},
+	}
+	if !slices.Equal(validationErr.Fields, want) {
+		t.Errorf("NewSong() fields = %v, want %v", validationErr.Fields, want)
+	}
+}
+
+func TestWebhookURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://example.com/hook", want: true},
+		{url: "http://93.184.216.34/hook", want: true},
+		{url: "ftp://example.com/hook", want: false},
+		{url: "/hook", want: false},
+		{url: "http://localhost:8080/hook", want: false},
+		{url: "http://api.localhost/hook", want: false},
+		{url: "http://127.0.0.1/hook", want: false},
+		{url: "http://10.0.0.5/hook", want: false},
+		{url: "http://100.64.0.1/hook", want: false},
+		{url: "http://100.127.255.254/hook", want: false},
+		{url: "http://100.128.0.1/hook", want: true},
+		{url: "http://169.254.169.254/latest", want: false},
+		{url: "http://[::1]/hook", want: false},
+		{url: "http://[::ffff:192.168.1.1]/hook", want: false},
+	}
+
+	for _, tt := range tests {
+		err := NewWebhook(entity.Webhook{URL: tt.url, Events: []string{string(entity.SongCreated)}})
+		if got := err == nil; got != tt.want {
+			t.Errorf("NewWebhook(%q) error = %v, want valid %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestNameCompletionPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		valid  bool
+	}{
+		{prefix: "", valid: false},
+		{prefix: "m", valid: false},
+		{prefix: " m ", valid: false},
+		{prefix: "U2", valid: true},
+		{prefix: "Ки", valid: true},
+	}
+
+	for _, tt := range tests {
+		err := NameCompletion(entity.NameCompletion{Field: "group", Prefix: tt.prefix, Limit: 10})
+		if got := err == nil; got != tt.valid {
+			t.Errorf("NameCompletion(prefix %q) error = %v, want valid %v", tt.prefix, err, tt.valid)
+		}
+	}
+}
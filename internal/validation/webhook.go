@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Zorynix/song-library/internal/entity"
+)
+
+const (
+	MaxWebhookURLLength    = 2048
+	MaxWebhookSecretLength = 255
+)
+
+func NewWebhook(webhook entity.Webhook) error {
+	var v Violations
+	webhookFields(&v, webhook)
+	return v.Err()
+}
+
+func Webhook(webhook entity.Webhook) error {
+	var v Violations
+	ID(&v, "id", webhook.ID)
+	webhookFields(&v, webhook)
+	return v.Err()
+}
+
+func WebhookID(id int64) error {
+	var v Violations
+	ID(&v, "id", id)
+	return v.Err()
+}
+
+func DeliveryFilter(filter entity.DeliveryFilter) error {
+	var v Violations
+	ID(&v, "id", filter.WebhookID)
+	switch filter.Status {
+	case "", entity.DeliveryPending, entity.DeliveryDelivered, entity.DeliveryDead:
+	default:
+		v.Add("status", fmt.Sprintf("must be one of %s, %s, %s", entity.DeliveryPending, entity.DeliveryDelivered, entity.DeliveryDead))
+	}
+	pagination(&v, filter.Limit, filter.Offset)
+	return v.Err()
+}
+
+func webhookFields(v *Violations, webhook entity.Webhook) {
+	if webhook.URL == "" {
+		v.Add("url", "is required")
+	} else if u, err := url.ParseRequestURI(webhook.URL); err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		v.Add("url", "must be an absolute http or https URL")
+	} else if !publicHost(u.Hostname()) {
+		v.Add("url", "must not point to a loopback, private or link-local address")
+	}
+	v.maxLength("url", webhook.URL, MaxWebhookURLLength)
+	v.maxLength("secret", webhook.Secret, MaxWebhookSecretLength)
+
+	if len(webhook.Events) == 0 {
+		v.Add("events", "must list at least one event")
+	}
+	for _, event := range webhook.Events {
+		if !slices.Contains(entity.SongEventTypes, entity.SongEventType(event)) {
+			v.Add("events", fmt.Sprintf("unknown event %q", event))
+		}
+	}
+}
+
+// sharedAddrs is RFC 6598 carrier-grade NAT, which netip does not count as private.
+var sharedAddrs = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddr reports whether webhooks may be sent to addr.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddrs.Contains(addr)
+}
+
+// publicHost does not resolve names; the dispatcher checks the dialed address.
+func publicHost(host string) bool {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return PublicAddr(addr)
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
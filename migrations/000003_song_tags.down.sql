@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS library.songs_tags_idx;
+
+ALTER TABLE library.songs DROP COLUMN IF EXISTS tags;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE library.songs ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX songs_tags_idx ON library.songs USING GIN (tags);
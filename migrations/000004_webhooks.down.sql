@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS library.webhook_deliveries;
+
+DROP TABLE IF EXISTS library.webhooks;
\ No newline at end of file
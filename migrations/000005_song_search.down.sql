@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS library.songs_search_idx;
+
+ALTER TABLE library.songs DROP COLUMN IF EXISTS search;
\ No newline at end of file
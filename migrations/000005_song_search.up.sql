@@ -0,0 +1,8 @@
+-- Title outranks group, which outranks lyrics: ts_rank weighs A > B > C.
+ALTER TABLE library.songs ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce("group", '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(text, '')), 'C')
+) STORED;
+
+CREATE INDEX songs_search_idx ON library.songs USING GIN (search);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS library.songs_title_trgm_idx;
+DROP INDEX IF EXISTS library.songs_group_trgm_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
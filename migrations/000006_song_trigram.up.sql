@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Serve both fuzzy matching and the existing ILIKE filters on these columns.
+CREATE INDEX songs_group_trgm_idx ON library.songs USING GIN ("group" gin_trgm_ops);
+CREATE INDEX songs_title_trgm_idx ON library.songs USING GIN (title gin_trgm_ops);
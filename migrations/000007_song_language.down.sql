@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS library.songs_language_idx;
+DROP INDEX IF EXISTS library.songs_search_idx;
+ALTER TABLE library.songs DROP COLUMN IF EXISTS search;
+
+ALTER TABLE library.songs ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce("group", '')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, coalesce(text, '')), 'C')
+) STORED;
+CREATE INDEX songs_search_idx ON library.songs USING GIN (search);
+
+ALTER TABLE library.songs DROP COLUMN IF EXISTS language;
+
+DROP FUNCTION IF EXISTS library.song_search_config(TEXT);
+DROP TEXT SEARCH CONFIGURATION IF EXISTS library.songs_en;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS library.songs_ru;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- The russian configuration already stems ASCII words as English; English
+-- lyrics get the same courtesy for the Russian words in them.
+CREATE TEXT SEARCH CONFIGURATION library.songs_ru (COPY = pg_catalog.russian);
+CREATE TEXT SEARCH CONFIGURATION library.songs_en (COPY = pg_catalog.english);
+ALTER TEXT SEARCH CONFIGURATION library.songs_en
+    ALTER MAPPING FOR word, hword, hword_part WITH russian_stem;
+
+CREATE FUNCTION library.song_search_config(language TEXT) RETURNS regconfig
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+        SELECT CASE language
+            WHEN 'ru' THEN 'library.songs_ru'::regconfig
+            WHEN 'en' THEN 'library.songs_en'::regconfig
+            ELSE 'simple'::regconfig
+        END
+    $$;
+
+ALTER TABLE library.songs ADD COLUMN language VARCHAR(2) NOT NULL DEFAULT '';
+
+-- Same rule as lyrics.DetectLanguage: whichever alphabet has more letters.
+UPDATE library.songs SET language = CASE
+    WHEN length(regexp_replace(text, '[^а-яё]', '', 'gi')) > length(regexp_replace(text, '[^a-z]', '', 'gi')) THEN 'ru'
+    WHEN text ~* '[a-z]' THEN 'en'
+    ELSE ''
+END;
+
+DROP INDEX IF EXISTS library.songs_search_idx;
+ALTER TABLE library.songs DROP COLUMN search;
+
+ALTER TABLE library.songs ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(library.song_search_config(language), coalesce(title, '')), 'A') ||
+    setweight(to_tsvector(library.song_search_config(language), coalesce("group", '')), 'B') ||
+    setweight(to_tsvector(library.song_search_config(language), coalesce(text, '')), 'C')
+) STORED;
+
+CREATE INDEX songs_search_idx ON library.songs USING GIN (search);
+CREATE INDEX songs_language_idx ON library.songs (language);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS library.songs_title_prefix_idx;
+DROP INDEX IF EXISTS library.songs_group_prefix_idx;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Autocomplete groups library.songs by these prefix indexes.
+CREATE INDEX songs_group_prefix_idx ON library.songs (lower("group") text_pattern_ops);
+CREATE INDEX songs_title_prefix_idx ON library.songs (lower(title) text_pattern_ops);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS songs_update_vector ON library.songs;
+DROP TRIGGER IF EXISTS songs_insert_vector ON library.songs;
+
+DROP MATERIALIZED VIEW IF EXISTS library.song_terms;
+DROP TABLE IF EXISTS library.song_vectors;
+
+DROP FUNCTION IF EXISTS library.refresh_song_vector();
\ No newline at end of file
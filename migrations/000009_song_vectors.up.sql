@@ -0,0 +1,55 @@
+-- Term frequency vectors of the lyrics for "more like this". Words are taken
+-- with the song's search configuration, so they are stemmed and stop words
+-- are dropped; weights are 1 + ln(occurrences).
+CREATE TABLE library.song_vectors (
+    song_id INTEGER PRIMARY KEY REFERENCES library.songs (id) ON DELETE CASCADE,
+    terms TEXT[] NOT NULL,
+    weights REAL[] NOT NULL
+);
+
+CREATE INDEX song_vectors_terms_idx ON library.song_vectors USING GIN (terms);
+
+CREATE FUNCTION library.refresh_song_vector() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+    BEGIN
+        INSERT INTO library.song_vectors (song_id, terms, weights)
+        SELECT NEW.id,
+               coalesce(array_agg(lexeme ORDER BY lexeme), '{}'),
+               coalesce(array_agg((1 + ln(cardinality(positions)))::real ORDER BY lexeme), '{}')
+        FROM unnest(to_tsvector(library.song_search_config(NEW.language), NEW.text))
+        ON CONFLICT (song_id) DO UPDATE
+            SET terms = EXCLUDED.terms, weights = EXCLUDED.weights;
+        RETURN NULL;
+    END
+    $$;
+
+-- Vectors are only recomputed when the lyrics or their language change.
+CREATE TRIGGER songs_insert_vector
+    AFTER INSERT ON library.songs
+    FOR EACH ROW EXECUTE FUNCTION library.refresh_song_vector();
+
+CREATE TRIGGER songs_update_vector
+    AFTER UPDATE OF text, language ON library.songs
+    FOR EACH ROW
+    WHEN (OLD.text IS DISTINCT FROM NEW.text OR OLD.language IS DISTINCT FROM NEW.language)
+    EXECUTE FUNCTION library.refresh_song_vector();
+
+INSERT INTO library.song_vectors (song_id, terms, weights)
+SELECT s.id,
+       coalesce(array_agg(v.lexeme ORDER BY v.lexeme) FILTER (WHERE v.lexeme IS NOT NULL), '{}'),
+       coalesce(array_agg((1 + ln(cardinality(v.positions)))::real ORDER BY v.lexeme) FILTER (WHERE v.lexeme IS NOT NULL), '{}')
+FROM library.songs s
+LEFT JOIN LATERAL unnest(to_tsvector(library.song_search_config(s.language), s.text)) AS v ON true
+GROUP BY s.id;
+
+-- Number of songs whose lyrics contain each word. It is refreshed by the
+-- server rather than by a trigger, so writes of songs sharing words do not
+-- queue behind each other's counter rows.
+CREATE MATERIALIZED VIEW library.song_terms AS
+SELECT term, count(*)::integer AS songs
+FROM library.song_vectors, unnest(terms) AS term
+GROUP BY term;
+
+-- Needed to refresh the view concurrently with reads.
+CREATE UNIQUE INDEX song_terms_term_idx ON library.song_terms (term);
@@ -0,0 +1,3 @@
+package songv1
+
+//go:generate protoc -I ../../../../api/proto --go_out=../../../.. --go_opt=module=github.com/Zorynix/song-library --go-grpc_out=../../../.. --go-grpc_opt=module=github.com/Zorynix/song-library song/v1/song.proto
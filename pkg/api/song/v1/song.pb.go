@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: song/v1/song.proto
+
+package songv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Song struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Group       string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	ReleaseDate string                 `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	Text        string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Link        string                 `protobuf:"bytes,6,opt,name=link,proto3" json:"link,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// language is the lyric language, "ru" or "en", or empty when unknown. It
+	// is detected from the text when an update leaves it empty.
+	Language      string `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Song) Reset() {
+	*x = Song{}
+	mi := &file_song_v1_song_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Song) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Song) ProtoMessage() {}
+
+func (x *Song) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Song.ProtoReflect.Descriptor instead.
+func (*Song) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Song) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Song) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Song) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Song) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Song) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Song) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Song) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Song) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SongFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Group string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text  string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// expression is a filter expression such as
+	// "group eq 'Muse' and releaseDate gt '2006-01-01'".
+	Expression string `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
+	// query is a full-text query over title, group and lyrics; matching songs
+	// are ordered by relevance.
+	Query string `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	// fuzzy matches group and title by similarity, tolerating typos.
+	Fuzzy bool `protobuf:"varint,6,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	// lang keeps only songs with lyrics in this language, "ru" or "en".
+	Lang          string `protobuf:"bytes,7,opt,name=lang,proto3" json:"lang,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SongFilter) Reset() {
+	*x = SongFilter{}
+	mi := &file_song_v1_song_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SongFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SongFilter) ProtoMessage() {}
+
+func (x *SongFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SongFilter.ProtoReflect.Descriptor instead.
+func (*SongFilter) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SongFilter) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *SongFilter) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SongFilter) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SongFilter) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *SongFilter) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SongFilter) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *SongFilter) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type ListSongsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *SongFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSongsRequest) Reset() {
+	*x = ListSongsRequest{}
+	mi := &file_song_v1_song_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSongsRequest) ProtoMessage() {}
+
+func (x *ListSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSongsRequest.ProtoReflect.Descriptor instead.
+func (*ListSongsRequest) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSongsRequest) GetFilter() *SongFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListSongsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSongsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListSongsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Songs         []*Song                `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSongsResponse) Reset() {
+	*x = ListSongsResponse{}
+	mi := &file_song_v1_song_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSongsResponse) ProtoMessage() {}
+
+func (x *ListSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSongsResponse.ProtoReflect.Descriptor instead.
+func (*ListSongsResponse) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSongsResponse) GetSongs() []*Song {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *ListSongsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetSongVersesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	SongId int64                  `protobuf:"varint,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// unit is "verse" (default) to page by stanzas or "line" to page by lines.
+	Unit          string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSongVersesRequest) Reset() {
+	*x = GetSongVersesRequest{}
+	mi := &file_song_v1_song_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSongVersesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongVersesRequest) ProtoMessage() {}
+
+func (x *GetSongVersesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongVersesRequest.ProtoReflect.Descriptor instead.
+func (*GetSongVersesRequest) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSongVersesRequest) GetSongId() int64 {
+	if x != nil {
+		return x.SongId
+	}
+	return 0
+}
+
+func (x *GetSongVersesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetSongVersesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetSongVersesRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type GetSongVersesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verses        []string               `protobuf:"bytes,1,rep,name=verses,proto3" json:"verses,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSongVersesResponse) Reset() {
+	*x = GetSongVersesResponse{}
+	mi := &file_song_v1_song_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSongVersesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongVersesResponse) ProtoMessage() {}
+
+func (x *GetSongVersesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongVersesResponse.ProtoReflect.Descriptor instead.
+func (*GetSongVersesResponse) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSongVersesResponse) GetVerses() []string {
+	if x != nil {
+		return x.Verses
+	}
+	return nil
+}
+
+func (x *GetSongVersesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type AddSongRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSongRequest) Reset() {
+	*x = AddSongRequest{}
+	mi := &file_song_v1_song_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSongRequest) ProtoMessage() {}
+
+func (x *AddSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSongRequest.ProtoReflect.Descriptor instead.
+func (*AddSongRequest) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddSongRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AddSongRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *AddSongRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type UpdateSongRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Song          *Song                  `protobuf:"bytes,1,opt,name=song,proto3" json:"song,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSongRequest) Reset() {
+	*x = UpdateSongRequest{}
+	mi := &file_song_v1_song_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongRequest) ProtoMessage() {}
+
+func (x *UpdateSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSongRequest) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateSongRequest) GetSong() *Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+type DeleteSongRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSongRequest) Reset() {
+	*x = DeleteSongRequest{}
+	mi := &file_song_v1_song_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongRequest) ProtoMessage() {}
+
+func (x *DeleteSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSongRequest) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteSongRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteSongResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSongResponse) Reset() {
+	*x = DeleteSongResponse{}
+	mi := &file_song_v1_song_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSongResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongResponse) ProtoMessage() {}
+
+func (x *DeleteSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_song_v1_song_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSongResponse) Descriptor() ([]byte, []int) {
+	return file_song_v1_song_proto_rawDescGZIP(), []int{9}
+}
+
+var File_song_v1_song_proto protoreflect.FileDescriptor
+
+const file_song_v1_song_proto_rawDesc = "" +
+	"\n" +
+	"\x12song/v1/song.proto\x12\asong.v1\"\xbd\x01\n" +
+	"\x04Song\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12!\n" +
+	"\frelease_date\x18\x04 \x01(\tR\vreleaseDate\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x12\n" +
+	"\x04link\x18\x06 \x01(\tR\x04link\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\"\xac\x01\n" +
+	"\n" +
+	"SongFilter\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x04 \x01(\tR\n" +
+	"expression\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12\x14\n" +
+	"\x05fuzzy\x18\x06 \x01(\bR\x05fuzzy\x12\x12\n" +
+	"\x04lang\x18\a \x01(\tR\x04lang\"m\n" +
+	"\x10ListSongsRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.song.v1.SongFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"N\n" +
+	"\x11ListSongsResponse\x12#\n" +
+	"\x05songs\x18\x01 \x03(\v2\r.song.v1.SongR\x05songs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"q\n" +
+	"\x14GetSongVersesRequest\x12\x17\n" +
+	"\asong_id\x18\x01 \x01(\x03R\x06songId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"E\n" +
+	"\x15GetSongVersesResponse\x12\x16\n" +
+	"\x06verses\x18\x01 \x03(\tR\x06verses\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"P\n" +
+	"\x0eAddSongRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"6\n" +
+	"\x11UpdateSongRequest\x12!\n" +
+	"\x04song\x18\x01 \x01(\v2\r.song.v1.SongR\x04song\"#\n" +
+	"\x11DeleteSongRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
+	"\x12DeleteSongResponse2\x8f\x03\n" +
+	"\vSongService\x12B\n" +
+	"\tListSongs\x12\x19.song.v1.ListSongsRequest\x1a\x1a.song.v1.ListSongsResponse\x129\n" +
+	"\vStreamSongs\x12\x19.song.v1.ListSongsRequest\x1a\r.song.v1.Song0\x01\x12N\n" +
+	"\rGetSongVerses\x12\x1d.song.v1.GetSongVersesRequest\x1a\x1e.song.v1.GetSongVersesResponse\x121\n" +
+	"\aAddSong\x12\x17.song.v1.AddSongRequest\x1a\r.song.v1.Song\x127\n" +
+	"\n" +
+	"UpdateSong\x12\x1a.song.v1.UpdateSongRequest\x1a\r.song.v1.Song\x12E\n" +
+	"\n" +
+	"DeleteSong\x12\x1a.song.v1.DeleteSongRequest\x1a\x1b.song.v1.DeleteSongResponseB8Z6github.com/Zorynix/song-library/pkg/api/song/v1;songv1b\x06proto3"
+
+var (
+	file_song_v1_song_proto_rawDescOnce sync.Once
+	file_song_v1_song_proto_rawDescData []byte
+)
+
+func file_song_v1_song_proto_rawDescGZIP() []byte {
+	file_song_v1_song_proto_rawDescOnce.Do(func() {
+		file_song_v1_song_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_song_v1_song_proto_rawDesc), len(file_song_v1_song_proto_rawDesc)))
+	})
+	return file_song_v1_song_proto_rawDescData
+}
+
+var file_song_v1_song_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_song_v1_song_proto_goTypes = []any{
+	(*Song)(nil),                  // 0: song.v1.Song
+	(*SongFilter)(nil),            // 1: song.v1.SongFilter
+	(*ListSongsRequest)(nil),      // 2: song.v1.ListSongsRequest
+	(*ListSongsResponse)(nil),     // 3: song.v1.ListSongsResponse
+	(*GetSongVersesRequest)(nil),  // 4: song.v1.GetSongVersesRequest
+	(*GetSongVersesResponse)(nil), // 5: song.v1.GetSongVersesResponse
+	(*AddSongRequest)(nil),        // 6: song.v1.AddSongRequest
+	(*UpdateSongRequest)(nil),     // 7: song.v1.UpdateSongRequest
+	(*DeleteSongRequest)(nil),     // 8: song.v1.DeleteSongRequest
+	(*DeleteSongResponse)(nil),    // 9: song.v1.DeleteSongResponse
+}
+var file_song_v1_song_proto_depIdxs = []int32{
+	1, // 0: song.v1.ListSongsRequest.filter:type_name -> song.v1.SongFilter
+	0, // 1: song.v1.ListSongsResponse.songs:type_name -> song.v1.Song
+	0, // 2: song.v1.UpdateSongRequest.song:type_name -> song.v1.Song
+	2, // 3: song.v1.SongService.ListSongs:input_type -> song.v1.ListSongsRequest
+	2, // 4: song.v1.SongService.StreamSongs:input_type -> song.v1.ListSongsRequest
+	4, // 5: song.v1.SongService.GetSongVerses:input_type -> song.v1.GetSongVersesRequest
+	6, // 6: song.v1.SongService.AddSong:input_type -> song.v1.AddSongRequest
+	7, // 7: song.v1.SongService.UpdateSong:input_type -> song.v1.UpdateSongRequest
+	8, // 8: song.v1.SongService.DeleteSong:input_type -> song.v1.DeleteSongRequest
+	3, // 9: song.v1.SongService.ListSongs:output_type -> song.v1.ListSongsResponse
+	0, // 10: song.v1.SongService.StreamSongs:output_type -> song.v1.Song
+	5, // 11: song.v1.SongService.GetSongVerses:output_type -> song.v1.GetSongVersesResponse
+	0, // 12: song.v1.SongService.AddSong:output_type -> song.v1.Song
+	0, // 13: song.v1.SongService.UpdateSong:output_type -> song.v1.Song
+	9, // 14: song.v1.SongService.DeleteSong:output_type -> song.v1.DeleteSongResponse
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_song_v1_song_proto_init() }
+func file_song_v1_song_proto_init() {
+	if File_song_v1_song_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_song_v1_song_proto_rawDesc), len(file_song_v1_song_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_song_v1_song_proto_goTypes,
+		DependencyIndexes: file_song_v1_song_proto_depIdxs,
+		MessageInfos:      file_song_v1_song_proto_msgTypes,
+	}.Build()
+	File_song_v1_song_proto = out.File
+	file_song_v1_song_proto_goTypes = nil
+	file_song_v1_song_proto_depIdxs = nil
+}